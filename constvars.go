@@ -8,3 +8,18 @@ const (
 	HAS_ONE = smysql.HAS_ONE // 有一个
 	HAS_LIST = smysql.HAS_LIST
 )
+
+type DECIMAL_MODE = smysql.DECIMAL_MODE
+
+const (
+	DECIMAL_AS_NUMBER = smysql.DECIMAL_AS_NUMBER // 默认，JSON 数字
+	DECIMAL_AS_STRING = smysql.DECIMAL_AS_STRING // JSON 字符串
+)
+
+type LOAD_DUPLICATE = smysql.LOAD_DUPLICATE
+
+const (
+	LOAD_DEFAULT = smysql.LOAD_DEFAULT // 默认，LOCAL 模式下按 IGNORE 处理
+	LOAD_REPLACE = smysql.LOAD_REPLACE // 替换唯一键重复的行
+	LOAD_IGNORE  = smysql.LOAD_IGNORE  // 跳过唯一键重复的行
+)
@@ -1,6 +1,10 @@
 package zmysql
 
 import (
+	"context"
+	"io"
+
+	"github.com/Xuzan9396/zmysql/smysql"
 	_ "github.com/go-sql-driver/mysql"
 )
 
@@ -20,3 +24,58 @@ func ExecByte(query string, isList IS_LIST_TYPE, args ...any) ([]byte, error) {
 func ExecProcByte(procName string, isList IS_LIST_TYPE, args ...any) ([]byte, error) {
 	return mysql_client.ExecProcByte(procName, isList, args...)
 }
+
+// ExecByteMulti 执行查询并返回所有结果集的 JSON，names 为空时返回数组，否则返回以 names 为键的对象
+func ExecByteMulti(query string, names []string, args ...any) ([]byte, error) {
+	return mysql_client.ExecByteMulti(query, names, args...)
+}
+
+// ExecProcByteMulti 执行存储过程并返回所有结果集的 JSON，names 为空时返回数组，否则返回以 names 为键的对象
+func ExecProcByteMulti(procName string, names []string, args ...any) ([]byte, error) {
+	return mysql_client.ExecProcByteMulti(procName, names, args...)
+}
+
+// StreamJSON 执行查询并将结果以 JSON 数组逐行写入 w，不在内存中保存整个结果集
+func StreamJSON(ctx context.Context, w io.Writer, query string, args ...any) error {
+	return mysql_client.StreamJSON(ctx, w, query, args...)
+}
+
+// StreamNDJSON 执行查询并将结果按 NDJSON（每行一个 JSON 对象）逐行写入 w
+func StreamNDJSON(ctx context.Context, w io.Writer, query string, args ...any) error {
+	return mysql_client.StreamNDJSON(ctx, w, query, args...)
+}
+
+// ExportCSV 执行查询并将结果按 CSV 逐行写入 w，NullAs 为 `\N` 时文件可以通过 LOAD DATA 导入
+func ExportCSV(ctx context.Context, w io.Writer, opts smysql.CSVOptions, query string, args ...any) error {
+	return mysql_client.ExportCSV(ctx, w, opts, query, args...)
+}
+
+// LoadData 通过 LOAD DATA LOCAL INFILE 将 reader 中的数据批量导入 table，服务端需要开启 local_infile
+func LoadData(ctx context.Context, table string, columns []string, reader io.Reader, opts smysql.LoadDataOptions) (*smysql.LoadResult, error) {
+	return mysql_client.LoadData(ctx, table, columns, reader, opts)
+}
+
+// ExecNamed 使用命名参数执行 SQL 并返回是否成功
+func ExecNamed(query string, arg any) (bool, error) {
+	return mysql_client.ExecNamed(query, arg)
+}
+
+// UpdateMap 根据 map 构建 UPDATE 语句并执行，返回受影响行数
+func UpdateMap(ctx context.Context, table string, data map[string]any, where string, args ...any) (int64, error) {
+	return mysql_client.UpdateMap(ctx, table, data, where, args...)
+}
+
+// Snapshot 对结构体做快照，用于之后只更新变化的字段
+func Snapshot(dest any) (*smysql.Snapshot, error) {
+	return mysql_client.Snapshot(dest)
+}
+
+// UpdateChanged 只更新快照以来发生变化的字段
+func UpdateChanged(ctx context.Context, table string, snap *smysql.Snapshot, where string, args ...any) (int64, error) {
+	return mysql_client.UpdateChanged(ctx, table, snap, where, args...)
+}
+
+// CallProc 调用存储过程，支持 IN、OUT、INOUT 参数和返回的结果集，参数使用 smysql.In、smysql.Out、smysql.InOut、smysql.ResultSet 创建
+func CallProc(ctx context.Context, procName string, params ...smysql.ProcParam) error {
+	return mysql_client.CallProc(ctx, procName, params...)
+}
@@ -44,6 +44,61 @@ func WithDebug() func(*smysql.MySQLClient) {
 	return smysql.WithDebug()
 }
 
+// WithAllowedColumns 设置表允许更新的列白名单
+func WithAllowedColumns(table string, columns ...string) func(*smysql.MySQLClient) {
+	return smysql.WithAllowedColumns(table, columns...)
+}
+
+// WithDecimalMode 设置 ExecByte 中 DECIMAL 列的 JSON 输出方式
+func WithDecimalMode(mode smysql.DECIMAL_MODE) func(*smysql.MySQLClient) {
+	return smysql.WithDecimalMode(mode)
+}
+
+// WithNameMapper 设置没有 db 标签的字段的列名转换函数
+func WithNameMapper(mapper func(fieldName string) string) func(*smysql.MySQLClient) {
+	return smysql.WithNameMapper(mapper)
+}
+
+// WithStrictMapping 启用严格映射，列与字段不匹配时返回错误
+func WithStrictMapping() func(*smysql.MySQLClient) {
+	return smysql.WithStrictMapping()
+}
+
+// WithMappingWarn 列与字段不匹配时通过日志警告
+func WithMappingWarn() func(*smysql.MySQLClient) {
+	return smysql.WithMappingWarn()
+}
+
+// WithAllowedProc 将存储过程加入白名单，paramCount 小于 0 时不校验参数个数
+func WithAllowedProc(procName string, paramCount int) func(*smysql.MySQLClient) {
+	return smysql.WithAllowedProc(procName, paramCount)
+}
+
+// WithProcValidation 调用存储过程前通过 information_schema 校验存储过程是否存在以及参数个数
+func WithProcValidation() func(*smysql.MySQLClient) {
+	return smysql.WithProcValidation()
+}
+
+// WithMultiStatements 允许 QueryResultSets、ExecByteMulti 一次执行多条语句，使用单独的连接池
+func WithMultiStatements() func(*smysql.MySQLClient) {
+	return smysql.WithMultiStatements()
+}
+
+// WithJSONKeyMapper 设置 ExecByte 等 JSON 输出中列名到键名的转换，如 smysql.CamelCase
+func WithJSONKeyMapper(mapper func(column string) string) func(*smysql.MySQLClient) {
+	return smysql.WithJSONKeyMapper(mapper)
+}
+
+// WithJSONOmitNull ExecByte 等 JSON 输出中省略值为 NULL 的键
+func WithJSONOmitNull() func(*smysql.MySQLClient) {
+	return smysql.WithJSONOmitNull()
+}
+
+// WithJSONBool 指定 ExecByte 等 JSON 输出中作为布尔值输出的列，不指定列时所有 TINYINT、BIT 列都输出为布尔值
+func WithJSONBool(columns ...string) func(*smysql.MySQLClient) {
+	return smysql.WithJSONBool(columns...)
+}
+
 // Close 关闭数据库连接
 func Close() error {
 	return mysql_client.Close()
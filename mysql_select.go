@@ -1,9 +1,13 @@
 package zmysql
 
-import "github.com/Xuzan9396/zmysql/smysql"
+import (
+	"context"
 
-// Find 执行查询并将结果映射到结构体中 列表查询
-func Find(dest any, query string, args ...any) error {
+	"github.com/Xuzan9396/zmysql/smysql"
+)
+
+// Find 执行查询并将结果映射到结构体中 列表查询，query 为 SQL 字符串或 smysql.Builder
+func Find(dest any, query any, args ...any) error {
 	return mysql_client.Find(dest, query, args...)
 }
 
@@ -12,8 +16,8 @@ func FindProc(dest any, procName string, args ...any) error {
 	return mysql_client.FindProc(dest, procName, args...)
 }
 
-// First 执行查询并将结果映射到结构体中，查询一条数据
-func First(dest any, query string, args ...any) (bool, error) {
+// First 执行查询并将结果映射到结构体中，查询一条数据，query 为 SQL 字符串或 smysql.Builder
+func First(dest any, query any, args ...any) (bool, error) {
 	return mysql_client.First(dest, query, args...)
 }
 
@@ -37,13 +41,23 @@ func FindMultipleProc(dest []any, procName string, args ...any) error {
 	return mysql_client.FindMultipleProc(dest, procName, args...)
 }
 
+// QueryResultSets 执行查询并返回多结果集游标，多条语句需要开启 WithMultiStatements 且不能使用 ? 参数
+func QueryResultSets(ctx context.Context, query string, args ...any) (*smysql.ResultSets, error) {
+	return mysql_client.QueryResultSets(ctx, query, args...)
+}
+
+// ProcResultSets 执行存储过程并返回多结果集游标
+func ProcResultSets(ctx context.Context, procName string, args ...any) (*smysql.ResultSets, error) {
+	return mysql_client.ProcResultSets(ctx, procName, args...)
+}
+
 // ExecFindLastId 执行SQL查询并返回LastInsertId
 func ExecFindLastId(query string, args ...any) (int64, error) {
 	return mysql_client.ExecFindLastId(query, args...)
 }
 
-// FindArray 执行查询并返回指定字段的泛型数组
-func FindArray[T int64 | string](fieldName string, query string, args ...any) ([]T, error) {
+// FindArray 执行查询并返回指定字段的泛型数组，query 为 SQL 字符串或 smysql.Builder
+func FindArray[T any](fieldName string, query any, args ...any) ([]T, error) {
 	return smysql.FindArray[T](mysql_client, fieldName, query, args...)
 }
 
@@ -58,7 +72,7 @@ func FindArrayString(fieldName string, query string, args ...any) ([]string, err
 }
 
 // FindProcArray 执行存储过程并返回指定字段的泛型数组
-func FindProcArray[T int64 | string](fieldName string, procName string, args ...any) ([]T, error) {
+func FindProcArray[T any](fieldName string, procName string, args ...any) ([]T, error) {
 	return smysql.FindProcArray[T](mysql_client, fieldName, procName, args...)
 }
 
@@ -72,8 +86,8 @@ func FindProcArrayString(fieldName string, procName string, args ...any) ([]stri
 	return mysql_client.FindProcArrayString(fieldName, procName, args...)
 }
 
-// FindMap 执行查询并返回泛型键值对映射
-func FindMap[T comparable, Y any](keyField string, valueField string, query string, args ...any) (map[T]Y, error) {
+// FindMap 执行查询并返回泛型键值对映射，query 为 SQL 字符串或 smysql.Builder
+func FindMap[T comparable, Y any](keyField string, valueField string, query any, args ...any) (map[T]Y, error) {
 	return smysql.FindMap[T, Y](mysql_client, keyField, valueField, query, args...)
 }
 
@@ -82,16 +96,76 @@ func FindProcMap[T comparable, Y any](keyField string, valueField string, procNa
 	return smysql.FindProcMap[T, Y](mysql_client, keyField, valueField, procName, args...)
 }
 
+// FindMapUnique 执行查询并返回泛型键值对映射，键重复时返回错误，query 为 SQL 字符串或 smysql.Builder
+func FindMapUnique[T comparable, Y any](keyField string, valueField string, query any, args ...any) (map[T]Y, error) {
+	return smysql.FindMapUnique[T, Y](mysql_client, keyField, valueField, query, args...)
+}
+
+// FindProcMapUnique 执行存储过程并返回泛型键值对映射，键重复时返回错误
+func FindProcMapUnique[T comparable, Y any](keyField string, valueField string, procName string, args ...any) (map[T]Y, error) {
+	return smysql.FindProcMapUnique[T, Y](mysql_client, keyField, valueField, procName, args...)
+}
+
+// FindGroup 执行查询并按键字段分组返回 map[K][]V
+func FindGroup[K comparable, V any](keyField string, query string, args ...any) (map[K][]V, error) {
+	return smysql.FindGroup[K, V](mysql_client, keyField, query, args...)
+}
+
+// FindProcGroup 执行存储过程并按键字段分组返回 map[K][]V
+func FindProcGroup[K comparable, V any](keyField string, procName string, args ...any) (map[K][]V, error) {
+	return smysql.FindProcGroup[K, V](mysql_client, keyField, procName, args...)
+}
+
+// FindMapKeys 执行查询并返回以多列为复合键的映射
+func FindMapKeys[K comparable, V any](keyFields []string, valueField string, query string, args ...any) (map[K]V, error) {
+	return smysql.FindMapKeys[K, V](mysql_client, keyFields, valueField, query, args...)
+}
+
+// FindProcMapKeys 执行存储过程并返回以多列为复合键的映射
+func FindProcMapKeys[K comparable, V any](keyFields []string, valueField string, procName string, args ...any) (map[K]V, error) {
+	return smysql.FindProcMapKeys[K, V](mysql_client, keyFields, valueField, procName, args...)
+}
+
+// FindOrdered 执行查询并按查询顺序返回键值对切片
+func FindOrdered[K comparable, V any](keyField string, valueField string, query string, args ...any) ([]smysql.KV[K, V], error) {
+	return smysql.FindOrdered[K, V](mysql_client, keyField, valueField, query, args...)
+}
+
+// FindProcOrdered 执行存储过程并按结果顺序返回键值对切片
+func FindProcOrdered[K comparable, V any](keyField string, valueField string, procName string, args ...any) ([]smysql.KV[K, V], error) {
+	return smysql.FindProcOrdered[K, V](mysql_client, keyField, valueField, procName, args...)
+}
+
 // FirstColAny 执行查询并返回指定类型的单列值（泛型版本）
-func FirstColAny[T int64 | string](query string, args ...any) (T, bool, error) {
+func FirstColAny[T any](query string, args ...any) (T, bool, error) {
 	return smysql.FirstColAny[T](mysql_client, query, args...)
 }
 
 // FirstColProcAny 执行存储过程并返回指定类型的单列值（泛型版本）
-func FirstColProcAny[T int64 | string](procName string, args ...any) (T, bool, error) {
+func FirstColProcAny[T any](procName string, args ...any) (T, bool, error) {
 	return smysql.FirstColProcAny[T](mysql_client, procName, args...)
 }
 
+// FindStructs 执行查询并返回结构体切片
+func FindStructs[T any](query string, args ...any) ([]T, error) {
+	return smysql.FindStructs[T](mysql_client, query, args...)
+}
+
+// FindProcStructs 执行存储过程并返回结构体切片
+func FindProcStructs[T any](procName string, args ...any) ([]T, error) {
+	return smysql.FindProcStructs[T](mysql_client, procName, args...)
+}
+
+// FirstStruct 执行查询并返回一条结构体数据
+func FirstStruct[T any](query string, args ...any) (T, bool, error) {
+	return smysql.FirstStruct[T](mysql_client, query, args...)
+}
+
+// FirstProcStruct 执行存储过程并返回一条结构体数据
+func FirstProcStruct[T any](procName string, args ...any) (T, bool, error) {
+	return smysql.FirstProcStruct[T](mysql_client, procName, args...)
+}
+
 // FirstColInt64 执行查询并返回int64类型的单列值
 func FirstColInt64(query string, args ...any) (int64, bool, error) {
 	return mysql_client.FirstColInt64(query, args...)
@@ -111,3 +185,33 @@ func FirstColProcInt64(procName string, args ...any) (int64, bool, error) {
 func FirstColProcString(procName string, args ...any) (string, bool, error) {
 	return mysql_client.FirstColProcString(procName, args...)
 }
+
+// FindNamed 使用命名参数执行查询并将结果映射到结构体切片中
+func FindNamed(dest any, query string, arg any) error {
+	return mysql_client.FindNamed(dest, query, arg)
+}
+
+// FirstNamed 使用命名参数执行查询并将结果映射到结构体中，查询一条数据
+func FirstNamed(dest any, query string, arg any) (bool, error) {
+	return mysql_client.FirstNamed(dest, query, arg)
+}
+
+// FindProcNamed 执行存储过程，参数按 paramNames 顺序从 arg 中取值
+func FindProcNamed(dest any, procName string, arg any, paramNames ...string) error {
+	return mysql_client.FindProcNamed(dest, procName, arg, paramNames...)
+}
+
+// FirstProcNamed 执行存储过程并查询一条数据，参数按 paramNames 顺序从 arg 中取值
+func FirstProcNamed(dest any, procName string, arg any, paramNames ...string) (bool, error) {
+	return mysql_client.FirstProcNamed(dest, procName, arg, paramNames...)
+}
+
+// Paginate 分页查询，返回当前页数据和总数信息
+func Paginate(ctx context.Context, dest any, query string, page, pageSize int, args ...any) (*smysql.Page, error) {
+	return mysql_client.Paginate(ctx, dest, query, page, pageSize, args...)
+}
+
+// PaginateCursor 游标分页查询，适用于深分页
+func PaginateCursor(ctx context.Context, dest any, b *smysql.SelectBuilder, cursor smysql.Cursor, pageSize int) (*smysql.CursorPage, error) {
+	return mysql_client.PaginateCursor(ctx, dest, b, cursor, pageSize)
+}
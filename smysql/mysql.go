@@ -1,6 +1,7 @@
 package smysql
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
@@ -30,10 +31,25 @@ type MySQLClient struct {
 	maxOpenConns    int
 	maxIdleConns    int
 	loc             string
+	location        *time.Location // loc 对应的时区，用于解析文本格式的时间
 	debug           bool
-
-	mu     sync.RWMutex
-	fields map[reflect.Type]map[string]int // Type -> {column name -> field index}
+	decimalMode     DECIMAL_MODE        // ExecByte 中 DECIMAL 列的输出方式
+	nameMapper      func(string) string // 没有 db 标签的字段名到列名的转换，nil 时忽略这类字段
+	mappingMode     MAPPING_MODE        // 结果集列与结构体字段不匹配时的处理方式
+
+	mu              sync.RWMutex
+	fields          map[reflect.Type]*fieldsMapping // Type -> 字段映射
+	allowedColumns  map[string]map[string]string    // table -> {lower column -> column}，列白名单
+	columnsCache    map[string]map[string]string    // table -> {lower column -> column}，表结构缓存
+	allowedProcs    map[string]int                  // lower proc -> 参数个数，存储过程白名单
+	procsCache      map[string]int                  // lower proc -> 参数个数，存储过程信息缓存
+	validateProcs   bool                            // 是否通过 information_schema 校验存储过程
+	multiStatements bool                            // 是否允许一次执行多条语句
+	multiDB         *sql.DB                         // 开启 multiStatements 的连接池，只用于 QueryResultSets
+	jsonKeyMapper   func(string) string             // ExecByte 中列名到 JSON 键名的转换
+	jsonOmitNull    bool                            // ExecByte 中是否省略 NULL 值的键
+	jsonBoolAll     bool                            // ExecByte 中所有 TINYINT、BIT 列输出为布尔值
+	jsonBoolColumns map[string]bool                 // lower column -> true，ExecByte 中输出为布尔值的列
 }
 
 // Conn 创建并初始化一个新的 MySQL 客户端
@@ -42,7 +58,11 @@ func Conn(username, password, addr, dbName string, opts ...func(*MySQLClient)) (
 		connMaxLifetime: 4 * time.Hour, // 默认连接最大生命周期
 		maxOpenConns:    100,           // 默认最大连接数
 		maxIdleConns:    50,            // 默认最大空闲连接数
-		fields:          make(map[reflect.Type]map[string]int),
+		fields:          make(map[reflect.Type]*fieldsMapping),
+		allowedColumns:  make(map[string]map[string]string),
+		columnsCache:    make(map[string]map[string]string),
+		allowedProcs:    make(map[string]int),
+		procsCache:      make(map[string]int),
 		loc:             url.QueryEscape("Local"),
 	}
 
@@ -51,6 +71,15 @@ func Conn(username, password, addr, dbName string, opts ...func(*MySQLClient)) (
 		opt(client)
 	}
 
+	locName, err := url.QueryUnescape(client.loc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid location: %v", err)
+	}
+	client.location, err = time.LoadLocation(locName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load location: %v", err)
+	}
+
 	// URL 编码用户名和密码
 	dsn := fmt.Sprintf("%s:%s@tcp(%s)/%s?charset=utf8mb4&collation=utf8mb4_unicode_ci&parseTime=true&loc=%s", username, password, addr, dbName, client.loc)
 
@@ -72,6 +101,19 @@ func Conn(username, password, addr, dbName string, opts ...func(*MySQLClient)) (
 	}
 	client.DB = db
 
+	// 多语句使用单独的连接池，不影响其他查询
+	if client.multiStatements {
+		multiDB, err := sql.Open("mysql", dsn+"&multiStatements=true")
+		if err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to open database: %v", err)
+		}
+		multiDB.SetConnMaxLifetime(client.connMaxLifetime)
+		multiDB.SetMaxOpenConns(client.maxOpenConns)
+		multiDB.SetMaxIdleConns(client.maxIdleConns)
+		client.multiDB = multiDB
+	}
+
 	return client, nil
 }
 
@@ -122,8 +164,32 @@ func (client *MySQLClient) debugLog(query string, args ...any) {
 	}
 }
 
+// fieldInfo 结构体字段映射信息
+type fieldInfo struct {
+	index []int        // 字段索引路径，同 reflect.Value.FieldByIndex
+	typ   reflect.Type // 字段类型
+	json  bool         // db:"name,json"，列值按 JSON 编解码
+}
+
+// fieldsMapping 结构体的列名到字段的映射
+type fieldsMapping struct {
+	columns map[string]*fieldInfo // 列名 -> 字段
+	folded  map[string]*fieldInfo // 小写列名 -> 字段，用于大小写不敏感匹配
+}
+
+// lookup 按列名查找字段，优先精确匹配，其次忽略大小写匹配
+func (m *fieldsMapping) lookup(column string) (*fieldInfo, bool) {
+	if info, ok := m.columns[column]; ok {
+		return info, true
+	}
+	info, ok := m.folded[strings.ToLower(column)]
+	return info, ok
+}
+
 // getFieldsMapping 获取结构体字段映射信息
-func (c *MySQLClient) getFieldsMapping(t reflect.Type) map[string]int {
+// 支持匿名嵌入的结构体及结构体指针，以及 db:"prefix_,inline" 形式的具名嵌套结构体，外层字段优先
+// db:"-" 的字段被忽略，没有 db 标签的字段在设置了 WithNameMapper 时按转换后的名称映射
+func (c *MySQLClient) getFieldsMapping(t reflect.Type) *fieldsMapping {
 	c.mu.RLock()
 	mapping, ok := c.fields[t]
 	c.mu.RUnlock()
@@ -134,32 +200,191 @@ func (c *MySQLClient) getFieldsMapping(t reflect.Type) map[string]int {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	mapping = make(map[string]int)
-	for i := 0; i < t.NumField(); i++ {
-		field := t.Field(i)
-		if dbTag := field.Tag.Get("db"); dbTag != "" {
-			mapping[dbTag] = i
+	columns := make(map[string]*fieldInfo)
+	depths := make(map[string]int)
+	collectFields(t, nil, "", c.nameMapper, columns, depths, map[reflect.Type]bool{})
+
+	mapping = &fieldsMapping{columns: columns, folded: make(map[string]*fieldInfo, len(columns))}
+	for column, info := range columns {
+		key := strings.ToLower(column)
+		// 忽略大小写后同名时取嵌套较浅的字段，深度相同时取字段顺序靠前的
+		if prev, ok := mapping.folded[key]; ok && !fieldBefore(prev.index, info.index) {
+			continue
 		}
+		mapping.folded[key] = info
 	}
 	c.fields[t] = mapping
 	return mapping
 }
 
+// fieldBefore 判断索引路径 a 是否优先于 b：嵌套更浅，或深度相同时声明顺序更靠前
+func fieldBefore(a, b []int) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
+
+// collectFields 递归收集字段映射，depths 记录列名对应字段的嵌套深度，同名列取深度最小的字段
+func collectFields(t reflect.Type, parent []int, prefix string, nameMapper func(string) string, mapping map[string]*fieldInfo, depths map[string]int, visiting map[reflect.Type]bool) {
+	if visiting[t] {
+		return
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		index := append(append([]int(nil), parent...), i)
+
+		name, opts, _ := strings.Cut(field.Tag.Get("db"), ",")
+		if name == "-" && opts == "" {
+			continue
+		}
+		structType := field.Type
+		if structType.Kind() == reflect.Ptr {
+			structType = structType.Elem()
+		}
+
+		// 匿名嵌入的结构体（无 db 标签）或 inline 的具名结构体，展开其字段
+		// 与 encoding/json 相同，未导出的字段只有匿名嵌入的非指针结构体可以展开，其它无法通过反射赋值，直接跳过
+		inline := (field.Anonymous && name == "") || hasTagOption(opts, "inline")
+		if inline && structType.Kind() == reflect.Struct {
+			if field.IsExported() || (field.Anonymous && field.Type.Kind() == reflect.Struct) {
+				collectFields(structType, index, prefix+name, nameMapper, mapping, depths, visiting)
+			}
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			if nameMapper == nil {
+				continue
+			}
+			name = nameMapper(field.Name)
+			if name == "" {
+				continue
+			}
+		}
+
+		column := prefix + name
+		if depth, ok := depths[column]; ok && depth <= len(index) {
+			continue
+		}
+		depths[column] = len(index)
+		mapping[column] = &fieldInfo{index: index, typ: field.Type, json: hasTagOption(opts, "json")}
+	}
+}
+
+// hasTagOption 判断 db 标签选项中是否包含 opt
+func hasTagOption(opts string, opt string) bool {
+	for opts != "" {
+		var cur string
+		cur, opts, _ = strings.Cut(opts, ",")
+		if strings.TrimSpace(cur) == opt {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldByIndexAlloc 按索引路径获取字段，路径上为 nil 的嵌入指针会自动分配
+func fieldByIndexAlloc(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+// fieldByIndex 按索引路径读取字段，路径上存在 nil 嵌入指针时返回 false
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	field, err := v.FieldByIndexErr(index)
+	if err != nil {
+		return reflect.Value{}, false
+	}
+	return field, true
+}
+
+// newScanDest 根据列名和字段映射创建扫描目标，未映射的列使用 sql.NullString 丢弃
+func (client *MySQLClient) newScanDest(columns []string, fieldsMapping *fieldsMapping) []any {
+	scanDest := make([]any, len(columns))
+	for i, col := range columns {
+		if info, ok := fieldsMapping.lookup(col); ok {
+			if info.json {
+				scanDest[i] = &jsonScanner{}
+			} else {
+				scanDest[i] = client.createNullScanner(info.typ)
+			}
+		} else {
+			scanDest[i] = &sql.NullString{}
+		}
+	}
+	return scanDest
+}
+
+// setStructFields 将一行扫描结果写入结构体
+func (client *MySQLClient) setStructFields(item reflect.Value, columns []string, scanDest []any, fieldsMapping *fieldsMapping) error {
+	for i, col := range columns {
+		if info, ok := fieldsMapping.lookup(col); ok {
+			field := fieldByIndexAlloc(item, info.index)
+			if err := client.setFieldFromNullScanner(field, scanDest[i], info.typ); err != nil {
+				return fmt.Errorf("failed to set field %s: %v", col, err)
+			}
+		}
+	}
+	return nil
+}
+
 // createNullScanner 根据字段类型创建对应的Null扫描器，只处理基本类型
+// 注册类型使用 RegisterType 的 decode，实现了 sql.Scanner 的类型直接扫描，
+// 基本类型、time.Time 和注册类型的指针使用 ptrScanner，NULL 时为 nil
 func (client *MySQLClient) createNullScanner(fieldType reflect.Type) any {
+	if codec := lookupCodec(fieldType); codec != nil && codec.decode != nil {
+		return &codecScanner{codec: codec}
+	}
+	if reflect.PointerTo(fieldType).Implements(scannerType) {
+		return reflect.New(fieldType).Interface()
+	}
+	if fieldType.Kind() == reflect.Ptr && isNullableElem(fieldType.Elem()) {
+		return &ptrScanner{inner: client.createNullScanner(fieldType.Elem())}
+	}
+	switch fieldType {
+	case timeType:
+		return &timeScanner{loc: client.location}
+	case durationType:
+		return &durationScanner{}
+	}
+
 	switch fieldType.Kind() {
 	case reflect.String:
 		return &sql.NullString{}
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		return &sql.NullInt64{}
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		return &sql.NullInt64{}
+		// BIGINT UNSIGNED 超过 int64 范围时驱动返回 uint64 或字符串，需要按 uint64 扫描
+		return &sql.Null[uint64]{}
 	case reflect.Float32, reflect.Float64:
 		return &sql.NullFloat64{}
 	case reflect.Bool:
 		return &sql.NullBool{}
-	default:
+	case reflect.Ptr, reflect.Interface:
 		return reflect.New(fieldType).Interface()
+	default:
+		// 扫描到 **T，NULL 时保持 nil，避免非 Scanner 类型遇到 NULL 报错
+		return reflect.New(reflect.PointerTo(fieldType)).Interface()
 	}
 }
 
@@ -179,11 +404,17 @@ func (client *MySQLClient) setFieldFromNullScanner(field reflect.Value, scanner
 		if s.Valid {
 			switch fieldType.Kind() {
 			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				if field.OverflowInt(s.Int64) {
+					return fmt.Errorf("value %d overflows %s", s.Int64, fieldType)
+				}
 				field.SetInt(s.Int64)
 			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 				if s.Int64 < 0 {
 					return fmt.Errorf("cannot convert negative value to unsigned type")
 				}
+				if field.OverflowUint(uint64(s.Int64)) {
+					return fmt.Errorf("value %d overflows %s", s.Int64, fieldType)
+				}
 				field.SetUint(uint64(s.Int64))
 			default:
 				return fmt.Errorf("cannot convert int64 to %s", fieldType.Kind())
@@ -196,11 +427,28 @@ func (client *MySQLClient) setFieldFromNullScanner(field reflect.Value, scanner
 				field.SetUint(0)
 			}
 		}
+	case *sql.Null[uint64]:
+		if s.Valid {
+			switch fieldType.Kind() {
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+				if field.OverflowUint(s.V) {
+					return fmt.Errorf("value %d overflows %s", s.V, fieldType)
+				}
+				field.SetUint(s.V)
+			default:
+				return fmt.Errorf("cannot convert uint64 to %s", fieldType.Kind())
+			}
+		} else {
+			field.SetUint(0)
+		}
 	case *sql.NullFloat64:
 		if s.Valid {
 			if fieldType.Kind() != reflect.Float32 && fieldType.Kind() != reflect.Float64 {
 				return fmt.Errorf("cannot convert float64 to %s", fieldType.Kind())
 			}
+			if field.OverflowFloat(s.Float64) {
+				return fmt.Errorf("value %v overflows %s", s.Float64, fieldType)
+			}
 			field.SetFloat(s.Float64)
 		} else {
 			field.SetFloat(0.0)
@@ -214,8 +462,49 @@ func (client *MySQLClient) setFieldFromNullScanner(field reflect.Value, scanner
 		} else {
 			field.SetBool(false)
 		}
+	case *timeScanner:
+		if s.valid {
+			field.Set(reflect.ValueOf(s.t))
+		} else {
+			field.Set(reflect.Zero(fieldType))
+		}
+	case *durationScanner:
+		field.SetInt(int64(s.d))
+	case *jsonScanner:
+		if s.data == nil {
+			field.Set(reflect.Zero(fieldType))
+			return nil
+		}
+		value := reflect.New(fieldType)
+		if err := json.Unmarshal(s.data, value.Interface()); err != nil {
+			return fmt.Errorf("failed to unmarshal json: %v", err)
+		}
+		field.Set(value.Elem())
+	case *codecScanner:
+		if !s.valid {
+			field.Set(reflect.Zero(fieldType))
+			return nil
+		}
+		field.Set(reflect.ValueOf(s.value))
+	case *ptrScanner:
+		if !s.valid {
+			field.Set(reflect.Zero(fieldType))
+			return nil
+		}
+		elem := reflect.New(fieldType.Elem())
+		if err := client.setFieldFromNullScanner(elem.Elem(), s.inner, fieldType.Elem()); err != nil {
+			return err
+		}
+		field.Set(elem)
 	default:
 		value := reflect.ValueOf(scanner).Elem()
+		if value.Type() == reflect.PointerTo(fieldType) {
+			if value.IsNil() {
+				field.Set(reflect.Zero(fieldType))
+				return nil
+			}
+			value = value.Elem()
+		}
 		field.Set(value)
 	}
 	return nil
@@ -228,18 +517,16 @@ func (client *MySQLClient) scanRows(rows *sql.Rows, destValue reflect.Value, sli
 		return fmt.Errorf("failed to get columns: %v", err)
 	}
 
-	fieldsMapping := client.getFieldsMapping(sliceElemType)
-	results := reflect.MakeSlice(destValue.Elem().Type(), 0, 0)
-	scanDest := make([]any, len(columns))
+	if sliceElemType.Kind() != reflect.Struct {
+		return fmt.Errorf("dest must be a pointer to a slice of struct, got slice of %s", sliceElemType)
+	}
 
-	for i := range columns {
-		if fieldIndex, ok := fieldsMapping[columns[i]]; ok {
-			fieldType := sliceElemType.Field(fieldIndex).Type
-			scanDest[i] = client.createNullScanner(fieldType)
-		} else {
-			scanDest[i] = &sql.NullString{}
-		}
+	fieldsMapping := client.getFieldsMapping(sliceElemType)
+	if err := client.checkMapping(sliceElemType, columns, fieldsMapping); err != nil {
+		return err
 	}
+	results := reflect.MakeSlice(destValue.Elem().Type(), 0, 0)
+	scanDest := client.newScanDest(columns, fieldsMapping)
 
 	for rows.Next() {
 		newItem := reflect.New(sliceElemType).Elem()
@@ -247,14 +534,8 @@ func (client *MySQLClient) scanRows(rows *sql.Rows, destValue reflect.Value, sli
 			return fmt.Errorf("failed to scan row: %v", err)
 		}
 
-		for i, col := range columns {
-			if fieldIndex, ok := fieldsMapping[col]; ok {
-				field := newItem.Field(fieldIndex)
-				fieldType := sliceElemType.Field(fieldIndex).Type
-				if err := client.setFieldFromNullScanner(field, scanDest[i], fieldType); err != nil {
-					return fmt.Errorf("failed to set field %s: %v", col, err)
-				}
-			}
+		if err := client.setStructFields(newItem, columns, scanDest, fieldsMapping); err != nil {
+			return err
 		}
 		results = reflect.Append(results, newItem)
 	}
@@ -267,8 +548,21 @@ func (client *MySQLClient) scanRows(rows *sql.Rows, destValue reflect.Value, sli
 	return nil
 }
 
-// Find 执行查询并将结果映射到结构体中 列表查询
-func (client *MySQLClient) Find(dest any, query string, args ...any) error {
+// Find 执行查询并将结果映射到结构体中 列表查询，query 为 SQL 字符串或 Builder
+func (client *MySQLClient) Find(dest any, query any, args ...any) error {
+	sqlQuery, args, err := resolveQuery(query, args)
+	if err != nil {
+		return err
+	}
+	return client.find(context.Background(), dest, sqlQuery, args...)
+}
+
+// find 执行查询并将结果映射到结构体中，支持 context
+func (client *MySQLClient) find(ctx context.Context, dest any, query string, args ...any) error {
+	query, args, err := expandArgs(query, args)
+	if err != nil {
+		return err
+	}
 	client.debugLog(query, args...)
 	destValue := reflect.ValueOf(dest)
 	if destValue.Kind() != reflect.Ptr || destValue.Elem().Kind() != reflect.Slice {
@@ -276,13 +570,13 @@ func (client *MySQLClient) Find(dest any, query string, args ...any) error {
 	}
 
 	sliceElemType := destValue.Elem().Type().Elem()
-	stmt, err := client.DB.Prepare(query)
+	stmt, err := client.DB.PrepareContext(ctx, query)
 	if err != nil {
 		return fmt.Errorf("failed to prepare query: %v", err)
 	}
 	defer stmt.Close()
 
-	rows, err := stmt.Query(args...)
+	rows, err := stmt.QueryContext(ctx, args...)
 	if err != nil {
 		return fmt.Errorf("failed to execute query: %v", err)
 	}
@@ -300,8 +594,10 @@ func (client *MySQLClient) FindProc(dest any, procName string, args ...any) erro
 	}
 
 	sliceElemType := destValue.Elem().Type().Elem()
-	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(args)), ",")
-	query := fmt.Sprintf("CALL `%s`(%s)", procName, placeholders)
+	query, args, err := client.procQuery(procName, args)
+	if err != nil {
+		return err
+	}
 
 	stmt, err := client.DB.Prepare(query)
 	if err != nil {
@@ -318,16 +614,24 @@ func (client *MySQLClient) FindProc(dest any, procName string, args ...any) erro
 	return client.scanRows(rows, destValue, sliceElemType)
 }
 
-// First 执行查询并将结果映射到结构体中，查询一条数据
-func (client *MySQLClient) First(dest any, query string, args ...any) (bool, error) {
-	client.debugLog(query, args...)
+// First 执行查询并将结果映射到结构体中，查询一条数据，query 为 SQL 字符串或 Builder
+func (client *MySQLClient) First(dest any, query any, args ...any) (bool, error) {
+	sqlQuery, args, err := resolveQuery(query, args)
+	if err != nil {
+		return false, err
+	}
+	sqlQuery, args, err = expandArgs(sqlQuery, args)
+	if err != nil {
+		return false, err
+	}
+	client.debugLog(sqlQuery, args...)
 	destValue := reflect.ValueOf(dest)
 	if destValue.Kind() != reflect.Ptr || destValue.Elem().Kind() != reflect.Struct {
 		return false, fmt.Errorf("dest must be a pointer to a struct")
 	}
 
 	structType := destValue.Elem().Type()
-	stmt, err := client.DB.Prepare(query)
+	stmt, err := client.DB.Prepare(sqlQuery)
 	if err != nil {
 		return false, fmt.Errorf("failed to prepare query: %v", err)
 	}
@@ -345,30 +649,18 @@ func (client *MySQLClient) First(dest any, query string, args ...any) (bool, err
 	}
 
 	fieldsMapping := client.getFieldsMapping(structType)
-	scanDest := make([]any, len(columns))
-
-	for i := range columns {
-		if fieldIndex, ok := fieldsMapping[columns[i]]; ok {
-			fieldType := structType.Field(fieldIndex).Type
-			scanDest[i] = client.createNullScanner(fieldType)
-		} else {
-			scanDest[i] = &sql.NullString{}
-		}
+	if err := client.checkMapping(structType, columns, fieldsMapping); err != nil {
+		return false, err
 	}
+	scanDest := client.newScanDest(columns, fieldsMapping)
 
 	if rows.Next() {
 		if err := rows.Scan(scanDest...); err != nil {
 			return false, fmt.Errorf("failed to scan row: %v", err)
 		}
 
-		for i, col := range columns {
-			if fieldIndex, ok := fieldsMapping[col]; ok {
-				field := destValue.Elem().Field(fieldIndex)
-				fieldType := structType.Field(fieldIndex).Type
-				if err := client.setFieldFromNullScanner(field, scanDest[i], fieldType); err != nil {
-					return false, fmt.Errorf("failed to set field %s: %v", col, err)
-				}
-			}
+		if err := client.setStructFields(destValue.Elem(), columns, scanDest, fieldsMapping); err != nil {
+			return false, err
 		}
 		return true, nil
 	}
@@ -385,8 +677,10 @@ func (client *MySQLClient) FirstProc(dest any, procName string, args ...any) (bo
 	}
 
 	structType := destValue.Elem().Type()
-	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(args)), ",")
-	query := fmt.Sprintf("CALL `%s`(%s)", procName, placeholders)
+	query, args, err := client.procQuery(procName, args)
+	if err != nil {
+		return false, err
+	}
 
 	stmt, err := client.DB.Prepare(query)
 	if err != nil {
@@ -406,30 +700,18 @@ func (client *MySQLClient) FirstProc(dest any, procName string, args ...any) (bo
 	}
 
 	fieldsMapping := client.getFieldsMapping(structType)
-	scanDest := make([]any, len(columns))
-
-	for i := range columns {
-		if fieldIndex, ok := fieldsMapping[columns[i]]; ok {
-			fieldType := structType.Field(fieldIndex).Type
-			scanDest[i] = client.createNullScanner(fieldType)
-		} else {
-			scanDest[i] = &sql.NullString{}
-		}
+	if err := client.checkMapping(structType, columns, fieldsMapping); err != nil {
+		return false, err
 	}
+	scanDest := client.newScanDest(columns, fieldsMapping)
 
 	if rows.Next() {
 		if err := rows.Scan(scanDest...); err != nil {
 			return false, fmt.Errorf("failed to scan row: %v", err)
 		}
 
-		for i, col := range columns {
-			if fieldIndex, ok := fieldsMapping[col]; ok {
-				field := destValue.Elem().Field(fieldIndex)
-				fieldType := structType.Field(fieldIndex).Type
-				if err := client.setFieldFromNullScanner(field, scanDest[i], fieldType); err != nil {
-					return false, fmt.Errorf("failed to set field %s: %v", col, err)
-				}
-			}
+		if err := client.setStructFields(destValue.Elem(), columns, scanDest, fieldsMapping); err != nil {
+			return false, err
 		}
 		return true, nil
 	}
@@ -439,6 +721,10 @@ func (client *MySQLClient) FirstProc(dest any, procName string, args ...any) (bo
 
 // FirstCol 执行查询并将单个字段值映射到基础类型
 func (client *MySQLClient) FirstCol(dest any, query string, args ...any) (bool, error) {
+	query, args, err := expandArgs(query, args)
+	if err != nil {
+		return false, err
+	}
 	client.debugLog(query, args...)
 	destValue := reflect.ValueOf(dest)
 	if destValue.Kind() != reflect.Ptr {
@@ -473,7 +759,7 @@ func (client *MySQLClient) FirstCol(dest any, query string, args ...any) (bool,
 		}
 
 		if err := client.setFieldFromNullScanner(destValue.Elem(), scanner, destValue.Elem().Type()); err != nil {
-			return false, fmt.Errorf("failed to set value: %v", err)
+			return false, fmt.Errorf("failed to set column %s: %v", columns[0], err)
 		}
 		return true, nil
 	}
@@ -489,8 +775,10 @@ func (client *MySQLClient) FirstColProc(dest any, procName string, args ...any)
 		return false, fmt.Errorf("dest must be a pointer to a basic type")
 	}
 
-	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(args)), ",")
-	query := fmt.Sprintf("CALL `%s`(%s)", procName, placeholders)
+	query, args, err := client.procQuery(procName, args)
+	if err != nil {
+		return false, err
+	}
 
 	stmt, err := client.DB.Prepare(query)
 	if err != nil {
@@ -520,7 +808,7 @@ func (client *MySQLClient) FirstColProc(dest any, procName string, args ...any)
 		}
 
 		if err := client.setFieldFromNullScanner(destValue.Elem(), scanner, destValue.Elem().Type()); err != nil {
-			return false, fmt.Errorf("failed to set value: %v", err)
+			return false, fmt.Errorf("failed to set column %s: %v", columns[0], err)
 		}
 		return true, nil
 	}
@@ -530,6 +818,10 @@ func (client *MySQLClient) FirstColProc(dest any, procName string, args ...any)
 
 // Exec 执行查询并返回是否成功
 func (client *MySQLClient) Exec(query string, args ...any) (bool, error) {
+	query, args, err := expandArgs(query, args)
+	if err != nil {
+		return false, err
+	}
 	client.debugLog(query, args...)
 	stmt, err := client.DB.Prepare(query)
 	if err != nil {
@@ -550,8 +842,35 @@ func (client *MySQLClient) Exec(query string, args ...any) (bool, error) {
 	return rowsAffected > 0, nil
 }
 
+// scanRowMaps 将结果集扫描为 []map[string]any，值按列类型转换为对应的 JSON 类型，见 jsonColumnValue
+func (client *MySQLClient) scanRowMaps(rows *sql.Rows) ([]map[string]any, error) {
+	encoder, err := client.newRowEncoder(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	var resultData []map[string]any
+
+	for rows.Next() {
+		rowMap, err := encoder.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		resultData = append(resultData, rowMap)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %v", err)
+	}
+	return resultData, nil
+}
+
 // ExecByte 执行原生 SQL 查询并返回结果集的所有数据，格式为 []byte
 func (client *MySQLClient) ExecByte(query string, isList IS_LIST_TYPE, args ...any) ([]byte, error) {
+	query, args, err := expandArgs(query, args)
+	if err != nil {
+		return nil, err
+	}
 	client.debugLog(query, args...)
 	stmt, err := client.DB.Prepare(query)
 	if err != nil {
@@ -565,34 +884,9 @@ func (client *MySQLClient) ExecByte(query string, isList IS_LIST_TYPE, args ...a
 	}
 	defer rows.Close()
 
-	columns, err := rows.Columns()
+	resultData, err := client.scanRowMaps(rows)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get columns: %v", err)
-	}
-
-	var resultData []map[string]any
-
-	for rows.Next() {
-		rowData := make([]any, len(columns))
-		rowPointers := make([]any, len(columns))
-		for i := range rowData {
-			rowPointers[i] = &rowData[i]
-		}
-
-		if err := rows.Scan(rowPointers...); err != nil {
-			return nil, fmt.Errorf("failed to scan row: %v", err)
-		}
-
-		rowMap := make(map[string]any)
-		for i, col := range columns {
-			rowMap[col] = rowData[i]
-		}
-
-		resultData = append(resultData, rowMap)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("rows iteration error: %v", err)
+		return nil, err
 	}
 
 	if isList == HAS_ONE && len(resultData) >= 1 {
@@ -614,8 +908,10 @@ func (client *MySQLClient) ExecByte(query string, isList IS_LIST_TYPE, args ...a
 // ExecProcByte 执行存储过程并返回结果集的所有数据，格式为 []byte
 func (client *MySQLClient) ExecProcByte(procName string, isList IS_LIST_TYPE, args ...any) ([]byte, error) {
 	client.debugLog(procName, args...)
-	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(args)), ",")
-	query := fmt.Sprintf("CALL `%s`(%s)", procName, placeholders)
+	query, args, err := client.procQuery(procName, args)
+	if err != nil {
+		return nil, err
+	}
 
 	stmt, err := client.DB.Prepare(query)
 	if err != nil {
@@ -629,34 +925,9 @@ func (client *MySQLClient) ExecProcByte(procName string, isList IS_LIST_TYPE, ar
 	}
 	defer rows.Close()
 
-	columns, err := rows.Columns()
+	resultData, err := client.scanRowMaps(rows)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get columns: %v", err)
-	}
-
-	var resultData []map[string]any
-
-	for rows.Next() {
-		rowData := make([]any, len(columns))
-		rowPointers := make([]any, len(columns))
-		for i := range rowData {
-			rowPointers[i] = &rowData[i]
-		}
-
-		if err := rows.Scan(rowPointers...); err != nil {
-			return nil, fmt.Errorf("failed to scan row: %v", err)
-		}
-
-		rowMap := make(map[string]any)
-		for i, col := range columns {
-			rowMap[col] = rowData[i]
-		}
-
-		resultData = append(resultData, rowMap)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("rows iteration error: %v", err)
+		return nil, err
 	}
 
 	if isList == HAS_ONE && len(resultData) >= 1 {
@@ -675,6 +946,67 @@ func (client *MySQLClient) ExecProcByte(procName string, isList IS_LIST_TYPE, ar
 	return jsonData, nil
 }
 
+// scanResultSet 将当前结果集映射到 dest，dest 为结构体切片指针或结构体指针，index 用于错误信息
+func (client *MySQLClient) scanResultSet(rows *sql.Rows, dest any, index int) error {
+	destValue := reflect.ValueOf(dest)
+	if destValue.Kind() != reflect.Ptr {
+		return fmt.Errorf("dest[%d] must be a pointer", index)
+	}
+
+	kind := destValue.Elem().Kind()
+	if kind != reflect.Slice && kind != reflect.Struct {
+		return fmt.Errorf("dest[%d] must be a pointer to a struct or slice", index)
+	}
+
+	sliceElemType := destValue.Elem().Type()
+	if kind == reflect.Slice {
+		sliceElemType = sliceElemType.Elem()
+	}
+
+	if kind == reflect.Slice {
+		if err := client.scanRows(rows, destValue, sliceElemType); err != nil {
+			return fmt.Errorf("failed to scan result set %d: %v", index, err)
+		}
+	} else {
+		if _, err := client.scanFirstRow(rows, destValue); err != nil {
+			return fmt.Errorf("result set %d: %v", index, err)
+		}
+	}
+	return nil
+}
+
+// scanFirstRow 将当前结果集的第一行映射到结构体指针 destValue，没有数据时返回 false
+func (client *MySQLClient) scanFirstRow(rows *sql.Rows, destValue reflect.Value) (bool, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return false, fmt.Errorf("failed to get columns: %v", err)
+	}
+
+	elemType := destValue.Elem().Type()
+	fieldsMapping := client.getFieldsMapping(elemType)
+	if err := client.checkMapping(elemType, columns, fieldsMapping); err != nil {
+		return false, err
+	}
+	scanDest := client.newScanDest(columns, fieldsMapping)
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return false, fmt.Errorf("rows iteration error: %v", err)
+		}
+		return false, nil
+	}
+	if err := rows.Scan(scanDest...); err != nil {
+		return false, fmt.Errorf("failed to scan row: %v", err)
+	}
+
+	newItem := reflect.New(elemType).Elem()
+	if err := client.setStructFields(newItem, columns, scanDest, fieldsMapping); err != nil {
+		return false, err
+	}
+	destValue.Elem().Set(newItem)
+	return true, nil
+}
+
 // FindMultipleProc 执行存储过程并将多个结果集映射到多个目标结构体或切片中
 func (client *MySQLClient) FindMultipleProc(dest []any, procName string, args ...any) error {
 	client.debugLog(procName, args...)
@@ -683,8 +1015,10 @@ func (client *MySQLClient) FindMultipleProc(dest []any, procName string, args ..
 		return fmt.Errorf("dest cannot be empty")
 	}
 
-	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(args)), ",")
-	query := fmt.Sprintf("CALL `%s`(%s)", procName, placeholders)
+	query, args, err := client.procQuery(procName, args)
+	if err != nil {
+		return err
+	}
 
 	stmt, err := client.DB.Prepare(query)
 	if err != nil {
@@ -703,60 +1037,8 @@ func (client *MySQLClient) FindMultipleProc(dest []any, procName string, args ..
 			return fmt.Errorf("too many result sets, expected %d", len(dest))
 		}
 
-		destValue := reflect.ValueOf(dest[index])
-		if destValue.Kind() != reflect.Ptr {
-			return fmt.Errorf("dest[%d] must be a pointer", index)
-		}
-
-		kind := destValue.Elem().Kind()
-		if kind != reflect.Slice && kind != reflect.Struct {
-			return fmt.Errorf("dest[%d] must be a pointer to a struct or slice", index)
-		}
-
-		sliceElemType := destValue.Elem().Type()
-		if kind == reflect.Slice {
-			sliceElemType = sliceElemType.Elem()
-		}
-
-		if kind == reflect.Slice {
-			if err := client.scanRows(rows, destValue, sliceElemType); err != nil {
-				return fmt.Errorf("failed to scan result set %d: %v", index, err)
-			}
-		} else {
-			columns, err := rows.Columns()
-			if err != nil {
-				return fmt.Errorf("failed to get columns: %v", err)
-			}
-
-			fieldsMapping := client.getFieldsMapping(sliceElemType)
-			scanDest := make([]any, len(columns))
-
-			for i := range columns {
-				if fieldIndex, ok := fieldsMapping[columns[i]]; ok {
-					fieldType := sliceElemType.Field(fieldIndex).Type
-					scanDest[i] = client.createNullScanner(fieldType)
-				} else {
-					scanDest[i] = &sql.NullString{}
-				}
-			}
-
-			if rows.Next() {
-				if err := rows.Scan(scanDest...); err != nil {
-					return fmt.Errorf("failed to scan row: %v", err)
-				}
-
-				newItem := reflect.New(sliceElemType).Elem()
-				for i, col := range columns {
-					if fieldIndex, ok := fieldsMapping[col]; ok {
-						field := newItem.Field(fieldIndex)
-						fieldType := sliceElemType.Field(fieldIndex).Type
-						if err := client.setFieldFromNullScanner(field, scanDest[i], fieldType); err != nil {
-							return fmt.Errorf("failed to set field %s: %v", col, err)
-						}
-					}
-				}
-				destValue.Elem().Set(newItem)
-			}
+		if err := client.scanResultSet(rows, dest[index], index); err != nil {
+			return err
 		}
 
 		if !rows.NextResultSet() {
@@ -769,6 +1051,10 @@ func (client *MySQLClient) FindMultipleProc(dest []any, procName string, args ..
 
 // ExecFindLastId 执行SQL查询并返回LastInsertId
 func (client *MySQLClient) ExecFindLastId(query string, args ...any) (int64, error) {
+	query, args, err := expandArgs(query, args)
+	if err != nil {
+		return 0, err
+	}
 	client.debugLog(query, args...)
 
 	stmt, err := client.DB.Prepare(query)
@@ -811,7 +1097,12 @@ func (client *MySQLClient) FirstColString(query string, args ...any) (string, bo
 }
 
 // firstColAny 执行查询并将单个字段值映射到泛型类型 - 包级泛型函数
-func firstColAny[T int64 | string](client *MySQLClient, query string, args ...any) (T, bool, error) {
+func firstColAny[T any](client *MySQLClient, query string, args ...any) (T, bool, error) {
+	query, args, err := expandArgs(query, args)
+	if err != nil {
+		var zero T
+		return zero, false, err
+	}
 	client.debugLog(query, args...)
 
 	stmt, err := client.DB.Prepare(query)
@@ -840,33 +1131,17 @@ func firstColAny[T int64 | string](client *MySQLClient, query string, args ...an
 	}
 
 	if rows.Next() {
-		var t T
-		switch any(t).(type) {
-		case int64:
-			var nullInt sql.NullInt64
-			if err := rows.Scan(&nullInt); err != nil {
-				var zero T
-				return zero, false, fmt.Errorf("failed to scan column: %v", err)
-			}
-			if nullInt.Valid {
-				return any(nullInt.Int64).(T), true, nil
-			} else {
-				var zero T
-				return zero, true, nil
-			}
-		case string:
-			var nullStr sql.NullString
-			if err := rows.Scan(&nullStr); err != nil {
-				var zero T
-				return zero, false, fmt.Errorf("failed to scan column: %v", err)
-			}
-			if nullStr.Valid {
-				return any(nullStr.String).(T), true, nil
-			} else {
-				var zero T
-				return zero, true, nil
-			}
+		scanner := client.createNullScanner(reflect.TypeOf((*T)(nil)).Elem())
+		if err := rows.Scan(scanner); err != nil {
+			var zero T
+			return zero, false, fmt.Errorf("failed to scan column: %v", err)
+		}
+		value, _, err := scanColumnValue[T](client, scanner, columns[0])
+		if err != nil {
+			var zero T
+			return zero, false, err
 		}
+		return value, true, nil
 	}
 
 	var zero T
@@ -875,11 +1150,14 @@ func firstColAny[T int64 | string](client *MySQLClient, query string, args ...an
 
 
 // firstColProcAny 执行存储过程并将单个字段值映射到泛型类型 - 包级泛型函数
-func firstColProcAny[T int64 | string](client *MySQLClient, procName string, args ...any) (T, bool, error) {
+func firstColProcAny[T any](client *MySQLClient, procName string, args ...any) (T, bool, error) {
 	client.debugLog(procName, args...)
 
-	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(args)), ",")
-	query := fmt.Sprintf("CALL `%s`(%s)", procName, placeholders)
+	query, args, err := client.procQuery(procName, args)
+	if err != nil {
+		var zero T
+		return zero, false, err
+	}
 
 	stmt, err := client.DB.Prepare(query)
 	if err != nil {
@@ -907,33 +1185,17 @@ func firstColProcAny[T int64 | string](client *MySQLClient, procName string, arg
 	}
 
 	if rows.Next() {
-		var t T
-		switch any(t).(type) {
-		case int64:
-			var nullInt sql.NullInt64
-			if err := rows.Scan(&nullInt); err != nil {
-				var zero T
-				return zero, false, fmt.Errorf("failed to scan column: %v", err)
-			}
-			if nullInt.Valid {
-				return any(nullInt.Int64).(T), true, nil
-			} else {
-				var zero T
-				return zero, true, nil
-			}
-		case string:
-			var nullStr sql.NullString
-			if err := rows.Scan(&nullStr); err != nil {
-				var zero T
-				return zero, false, fmt.Errorf("failed to scan column: %v", err)
-			}
-			if nullStr.Valid {
-				return any(nullStr.String).(T), true, nil
-			} else {
-				var zero T
-				return zero, true, nil
-			}
+		scanner := client.createNullScanner(reflect.TypeOf((*T)(nil)).Elem())
+		if err := rows.Scan(scanner); err != nil {
+			var zero T
+			return zero, false, fmt.Errorf("failed to scan column: %v", err)
 		}
+		value, _, err := scanColumnValue[T](client, scanner, columns[0])
+		if err != nil {
+			var zero T
+			return zero, false, err
+		}
+		return value, true, nil
 	}
 
 	var zero T
@@ -942,7 +1204,11 @@ func firstColProcAny[T int64 | string](client *MySQLClient, procName string, arg
 
 
 // findArray 执行查询并返回指定字段的泛型数组 - 包级泛型函数
-func findArray[T int64 | string](client *MySQLClient, fieldName string, query string, args ...any) ([]T, error) {
+func findArray[T any](client *MySQLClient, fieldName string, query string, args ...any) ([]T, error) {
+	query, args, err := expandArgs(query, args)
+	if err != nil {
+		return nil, err
+	}
 	client.debugLog(query, args...)
 
 	stmt, err := client.DB.Prepare(query)
@@ -978,17 +1244,13 @@ func findArray[T int64 | string](client *MySQLClient, fieldName string, query st
 	var results []T
 	scanDest := make([]any, len(columns))
 
+	elemType := reflect.TypeOf((*T)(nil)).Elem()
+
 	for rows.Next() {
 		// 为每列创建扫描目标
 		for i := range columns {
 			if i == fieldIndex {
-				var t T
-				switch any(t).(type) {
-				case int64:
-					scanDest[i] = &sql.NullInt64{}
-				case string:
-					scanDest[i] = &sql.NullString{}
-				}
+				scanDest[i] = client.createNullScanner(elemType)
 			} else {
 				scanDest[i] = &sql.NullString{} // 忽略其他字段
 			}
@@ -998,21 +1260,14 @@ func findArray[T int64 | string](client *MySQLClient, fieldName string, query st
 			return nil, fmt.Errorf("failed to scan row: %v", err)
 		}
 
-		// 获取目标字段值
-		var t T
-		switch any(t).(type) {
-		case int64:
-			nullInt := scanDest[fieldIndex].(*sql.NullInt64)
-			if nullInt.Valid {
-				results = append(results, any(nullInt.Int64).(T))
-			}
-		case string:
-			nullStr := scanDest[fieldIndex].(*sql.NullString)
-			if nullStr.Valid {
-				results = append(results, any(nullStr.String).(T))
-			}
+		// 获取目标字段值，NULL值不添加到结果切片中
+		value, valid, err := scanColumnValue[T](client, scanDest[fieldIndex], fieldName)
+		if err != nil {
+			return nil, err
+		}
+		if valid {
+			results = append(results, value)
 		}
-		// NULL值不添加到结果切片中
 	}
 
 	if err := rows.Err(); err != nil {
@@ -1028,11 +1283,13 @@ func findArray[T int64 | string](client *MySQLClient, fieldName string, query st
 
 
 // findProcArray 执行存储过程并返回指定字段的泛型数组 - 包级泛型函数
-func findProcArray[T int64 | string](client *MySQLClient, fieldName string, procName string, args ...any) ([]T, error) {
+func findProcArray[T any](client *MySQLClient, fieldName string, procName string, args ...any) ([]T, error) {
 	client.debugLog(procName, args...)
 
-	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(args)), ",")
-	query := fmt.Sprintf("CALL `%s`(%s)", procName, placeholders)
+	query, args, err := client.procQuery(procName, args)
+	if err != nil {
+		return nil, err
+	}
 
 	stmt, err := client.DB.Prepare(query)
 	if err != nil {
@@ -1067,17 +1324,13 @@ func findProcArray[T int64 | string](client *MySQLClient, fieldName string, proc
 	var results []T
 	scanDest := make([]any, len(columns))
 
+	elemType := reflect.TypeOf((*T)(nil)).Elem()
+
 	for rows.Next() {
 		// 为每列创建扫描目标
 		for i := range columns {
 			if i == fieldIndex {
-				var t T
-				switch any(t).(type) {
-				case int64:
-					scanDest[i] = &sql.NullInt64{}
-				case string:
-					scanDest[i] = &sql.NullString{}
-				}
+				scanDest[i] = client.createNullScanner(elemType)
 			} else {
 				scanDest[i] = &sql.NullString{} // 忽略其他字段
 			}
@@ -1087,21 +1340,14 @@ func findProcArray[T int64 | string](client *MySQLClient, fieldName string, proc
 			return nil, fmt.Errorf("failed to scan row: %v", err)
 		}
 
-		// 获取目标字段值
-		var t T
-		switch any(t).(type) {
-		case int64:
-			nullInt := scanDest[fieldIndex].(*sql.NullInt64)
-			if nullInt.Valid {
-				results = append(results, any(nullInt.Int64).(T))
-			}
-		case string:
-			nullStr := scanDest[fieldIndex].(*sql.NullString)
-			if nullStr.Valid {
-				results = append(results, any(nullStr.String).(T))
-			}
+		// 获取目标字段值，NULL值不添加到结果切片中
+		value, valid, err := scanColumnValue[T](client, scanDest[fieldIndex], fieldName)
+		if err != nil {
+			return nil, err
+		}
+		if valid {
+			results = append(results, value)
 		}
-		// NULL值不添加到结果切片中
 	}
 
 	if err := rows.Err(); err != nil {
@@ -1136,11 +1382,17 @@ func (client *MySQLClient) FindProcArrayString(fieldName string, procName string
 	return findProcArray[string](client, fieldName, procName, args...)
 }
 
+
 // findMap 执行查询并返回map[T]Y，支持泛型键值类型 - 包级泛型函数
 // keyField: 键字段名，不能为空
-// valueField: 值字段名，为空时返回整个结构体
+// valueField: 值字段名，为空时返回整个结构体，值不是结构体时使用键以外唯一的列
+// unique: 为 true 时键重复返回错误，否则后面的行覆盖前面的行
 // key为null的行会被过滤掉
-func findMap[T comparable, Y any](client *MySQLClient, keyField string, valueField string, query string, args ...any) (map[T]Y, error) {
+func findMap[T comparable, Y any](client *MySQLClient, keyField string, valueField string, unique bool, query string, args ...any) (map[T]Y, error) {
+	query, args, err := expandArgs(query, args)
+	if err != nil {
+		return nil, err
+	}
 	client.debugLog(query, args...)
 
 	if keyField == "" {
@@ -1159,146 +1411,16 @@ func findMap[T comparable, Y any](client *MySQLClient, keyField string, valueFie
 	}
 	defer rows.Close()
 
-	columns, err := rows.Columns()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get columns: %v", err)
-	}
-
-	// 查找键字段索引
-	keyIndex := -1
-	valueIndex := -1
-	for i, col := range columns {
-		if col == keyField {
-			keyIndex = i
-		}
-		if valueField != "" && col == valueField {
-			valueIndex = i
-		}
-	}
-
-	if keyIndex == -1 {
-		return nil, fmt.Errorf("key field '%s' not found in query results", keyField)
-	}
-	if valueField != "" && valueIndex == -1 {
-		return nil, fmt.Errorf("value field '%s' not found in query results", valueField)
-	}
-
 	result := make(map[T]Y)
-	scanDest := make([]any, len(columns))
-
-	// 检查Y是否为结构体类型
-	var yType reflect.Type
-	var yValue Y
-	yValueRef := reflect.ValueOf(&yValue).Elem()
-	yType = yValueRef.Type()
-	isStructType := yType.Kind() == reflect.Struct
-
-	// 如果Y是结构体类型，获取字段映射
-	var fieldsMapping map[string]int
-	if isStructType {
-		fieldsMapping = client.getFieldsMapping(yType)
-	}
-
-	for rows.Next() {
-		// 为每列创建扫描目标
-		for i := range columns {
-			if isStructType && valueField == "" {
-				// 结构体模式，根据字段类型创建扫描器
-				if fieldIndex, ok := fieldsMapping[columns[i]]; ok {
-					fieldType := yType.Field(fieldIndex).Type
-					scanDest[i] = client.createNullScanner(fieldType)
-				} else {
-					scanDest[i] = &sql.NullString{}
-				}
-			} else {
-				// 基础类型模式，使用NullString扫描器
-				scanDest[i] = &sql.NullString{}
-			}
-		}
-
-		if err := rows.Scan(scanDest...); err != nil {
-			return nil, fmt.Errorf("failed to scan row: %v", err)
-		}
-
-		// 获取键值，如果键为null则跳过该行
-		var key T
-		keyScanner := scanDest[keyIndex]
-		keyValid := false
-
-		switch s := keyScanner.(type) {
-		case *sql.NullString:
-			if s.Valid {
-				keyValid = true
-				// 尝试转换为T类型
-				switch any(key).(type) {
-				case string:
-					key = any(s.String).(T)
-				case int64:
-					// 需要转换字符串为int64
-					var val int64
-					if _, err := fmt.Sscanf(s.String, "%d", &val); err == nil {
-						key = any(val).(T)
-					}
-				}
-			}
-		case *sql.NullInt64:
-			if s.Valid {
-				keyValid = true
-				switch any(key).(type) {
-				case int64:
-					key = any(s.Int64).(T)
-				case string:
-					key = any(fmt.Sprintf("%d", s.Int64)).(T)
-				}
-			}
+	err = scanKeyedRows[T, Y](client, rows, []string{keyField}, valueField, func(key T, value Y) error {
+		if _, ok := result[key]; ok && unique {
+			return fmt.Errorf("duplicate key '%v' for field '%s'", key, keyField)
 		}
-
-		// 如果键为null，跳过该行
-		if !keyValid {
-			continue
-		}
-
-		// 构建值
-		var value Y
-		if valueField == "" && isStructType {
-			// 返回整个结构体
-			newStruct := reflect.New(yType).Elem()
-			for i, col := range columns {
-				if fieldIndex, ok := fieldsMapping[col]; ok {
-					field := newStruct.Field(fieldIndex)
-					fieldType := yType.Field(fieldIndex).Type
-					if err := client.setFieldFromNullScanner(field, scanDest[i], fieldType); err != nil {
-						return nil, fmt.Errorf("failed to set field %s: %v", col, err)
-					}
-				}
-			}
-			value = newStruct.Interface().(Y)
-		} else {
-			// 返回指定字段值
-			valueScanner := scanDest[valueIndex].(*sql.NullString)
-			if valueScanner.Valid {
-				// 尝试转换为Y类型
-				switch any(value).(type) {
-				case string:
-					value = any(valueScanner.String).(Y)
-				case int64:
-					var val int64
-					if _, err := fmt.Sscanf(valueScanner.String, "%d", &val); err == nil {
-						value = any(val).(Y)
-					}
-				}
-			} else {
-				// 值为null，使用Y类型的零值
-				var zero Y
-				value = zero
-			}
-		}
-
 		result[key] = value
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("rows iteration error: %v", err)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	if len(result) == 0 {
@@ -1311,17 +1433,20 @@ func findMap[T comparable, Y any](client *MySQLClient, keyField string, valueFie
 
 // findProcMap 执行存储过程并返回map[T]Y，支持泛型键值类型 - 包级泛型函数
 // keyField: 键字段名，不能为空
-// valueField: 值字段名，为空时返回整个结构体
+// valueField: 值字段名，为空时返回整个结构体，值不是结构体时使用键以外唯一的列
+// unique: 为 true 时键重复返回错误，否则后面的行覆盖前面的行
 // key为null的行会被过滤掉
-func findProcMap[T comparable, Y any](client *MySQLClient, keyField string, valueField string, procName string, args ...any) (map[T]Y, error) {
+func findProcMap[T comparable, Y any](client *MySQLClient, keyField string, valueField string, unique bool, procName string, args ...any) (map[T]Y, error) {
 	client.debugLog(procName, args...)
 
 	if keyField == "" {
 		return nil, fmt.Errorf("keyField cannot be empty")
 	}
 
-	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(args)), ",")
-	query := fmt.Sprintf("CALL `%s`(%s)", procName, placeholders)
+	query, args, err := client.procQuery(procName, args)
+	if err != nil {
+		return nil, err
+	}
 
 	stmt, err := client.DB.Prepare(query)
 	if err != nil {
@@ -1335,146 +1460,16 @@ func findProcMap[T comparable, Y any](client *MySQLClient, keyField string, valu
 	}
 	defer rows.Close()
 
-	columns, err := rows.Columns()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get columns: %v", err)
-	}
-
-	// 查找键字段索引
-	keyIndex := -1
-	valueIndex := -1
-	for i, col := range columns {
-		if col == keyField {
-			keyIndex = i
-		}
-		if valueField != "" && col == valueField {
-			valueIndex = i
-		}
-	}
-
-	if keyIndex == -1 {
-		return nil, fmt.Errorf("key field '%s' not found in query results", keyField)
-	}
-	if valueField != "" && valueIndex == -1 {
-		return nil, fmt.Errorf("value field '%s' not found in query results", valueField)
-	}
-
 	result := make(map[T]Y)
-	scanDest := make([]any, len(columns))
-
-	// 检查Y是否为结构体类型
-	var yType reflect.Type
-	var yValue Y
-	yValueRef := reflect.ValueOf(&yValue).Elem()
-	yType = yValueRef.Type()
-	isStructType := yType.Kind() == reflect.Struct
-
-	// 如果Y是结构体类型，获取字段映射
-	var fieldsMapping map[string]int
-	if isStructType {
-		fieldsMapping = client.getFieldsMapping(yType)
-	}
-
-	for rows.Next() {
-		// 为每列创建扫描目标
-		for i := range columns {
-			if isStructType && valueField == "" {
-				// 结构体模式，根据字段类型创建扫描器
-				if fieldIndex, ok := fieldsMapping[columns[i]]; ok {
-					fieldType := yType.Field(fieldIndex).Type
-					scanDest[i] = client.createNullScanner(fieldType)
-				} else {
-					scanDest[i] = &sql.NullString{}
-				}
-			} else {
-				// 基础类型模式，使用NullString扫描器
-				scanDest[i] = &sql.NullString{}
-			}
-		}
-
-		if err := rows.Scan(scanDest...); err != nil {
-			return nil, fmt.Errorf("failed to scan row: %v", err)
-		}
-
-		// 获取键值，如果键为null则跳过该行
-		var key T
-		keyScanner := scanDest[keyIndex]
-		keyValid := false
-
-		switch s := keyScanner.(type) {
-		case *sql.NullString:
-			if s.Valid {
-				keyValid = true
-				// 尝试转换为T类型
-				switch any(key).(type) {
-				case string:
-					key = any(s.String).(T)
-				case int64:
-					// 需要转换字符串为int64
-					var val int64
-					if _, err := fmt.Sscanf(s.String, "%d", &val); err == nil {
-						key = any(val).(T)
-					}
-				}
-			}
-		case *sql.NullInt64:
-			if s.Valid {
-				keyValid = true
-				switch any(key).(type) {
-				case int64:
-					key = any(s.Int64).(T)
-				case string:
-					key = any(fmt.Sprintf("%d", s.Int64)).(T)
-				}
-			}
+	err = scanKeyedRows[T, Y](client, rows, []string{keyField}, valueField, func(key T, value Y) error {
+		if _, ok := result[key]; ok && unique {
+			return fmt.Errorf("duplicate key '%v' for field '%s'", key, keyField)
 		}
-
-		// 如果键为null，跳过该行
-		if !keyValid {
-			continue
-		}
-
-		// 构建值
-		var value Y
-		if valueField == "" && isStructType {
-			// 返回整个结构体
-			newStruct := reflect.New(yType).Elem()
-			for i, col := range columns {
-				if fieldIndex, ok := fieldsMapping[col]; ok {
-					field := newStruct.Field(fieldIndex)
-					fieldType := yType.Field(fieldIndex).Type
-					if err := client.setFieldFromNullScanner(field, scanDest[i], fieldType); err != nil {
-						return nil, fmt.Errorf("failed to set field %s: %v", col, err)
-					}
-				}
-			}
-			value = newStruct.Interface().(Y)
-		} else {
-			// 返回指定字段值
-			valueScanner := scanDest[valueIndex].(*sql.NullString)
-			if valueScanner.Valid {
-				// 尝试转换为Y类型
-				switch any(value).(type) {
-				case string:
-					value = any(valueScanner.String).(Y)
-				case int64:
-					var val int64
-					if _, err := fmt.Sscanf(valueScanner.String, "%d", &val); err == nil {
-						value = any(val).(Y)
-					}
-				}
-			} else {
-				// 值为null，使用Y类型的零值
-				var zero Y
-				value = zero
-			}
-		}
-
 		result[key] = value
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("rows iteration error: %v", err)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	if len(result) == 0 {
@@ -1486,37 +1481,94 @@ func findProcMap[T comparable, Y any](client *MySQLClient, keyField string, valu
 
 // 包级泛型函数，由于 Go 不支持方法泛型
 
-// FindArray 执行查询并返回指定字段的泛型数组 - 包级函数
-func FindArray[T int64 | string](client *MySQLClient, fieldName string, query string, args ...any) ([]T, error) {
-	return findArray[T](client, fieldName, query, args...)
+// FindArray 执行查询并返回指定字段的泛型数组，query 为 SQL 字符串或 Builder - 包级函数
+func FindArray[T any](client *MySQLClient, fieldName string, query any, args ...any) ([]T, error) {
+	sqlQuery, args, err := resolveQuery(query, args)
+	if err != nil {
+		return nil, err
+	}
+	return findArray[T](client, fieldName, sqlQuery, args...)
 }
 
 // FindProcArray 执行存储过程并返回指定字段的泛型数组 - 包级函数
-func FindProcArray[T int64 | string](client *MySQLClient, fieldName string, procName string, args ...any) ([]T, error) {
+func FindProcArray[T any](client *MySQLClient, fieldName string, procName string, args ...any) ([]T, error) {
 	return findProcArray[T](client, fieldName, procName, args...)
 }
 
 // FirstColAny 执行查询并将单个字段值映射到指定类型（泛型版本）- 包级函数
-func FirstColAny[T int64 | string](client *MySQLClient, query string, args ...any) (T, bool, error) {
+func FirstColAny[T any](client *MySQLClient, query string, args ...any) (T, bool, error) {
 	return firstColAny[T](client, query, args...)
 }
 
 // FirstColProcAny 执行存储过程并将单个字段值映射到指定类型（泛型版本）- 包级函数
-func FirstColProcAny[T int64 | string](client *MySQLClient, procName string, args ...any) (T, bool, error) {
+func FirstColProcAny[T any](client *MySQLClient, procName string, args ...any) (T, bool, error) {
 	return firstColProcAny[T](client, procName, args...)
 }
 
-// FindMap 执行查询并返回map[T]Y，支持泛型键值类型 - 包级函数
-func FindMap[T comparable, Y any](client *MySQLClient, keyField string, valueField string, query string, args ...any) (map[T]Y, error) {
-	return findMap[T, Y](client, keyField, valueField, query, args...)
+// FindMap 执行查询并返回map[T]Y，支持泛型键值类型，query 为 SQL 字符串或 Builder - 包级函数
+func FindMap[T comparable, Y any](client *MySQLClient, keyField string, valueField string, query any, args ...any) (map[T]Y, error) {
+	sqlQuery, args, err := resolveQuery(query, args)
+	if err != nil {
+		return nil, err
+	}
+	return findMap[T, Y](client, keyField, valueField, false, sqlQuery, args...)
 }
 
 // FindProcMap 执行存储过程并返回map[T]Y，支持泛型键值类型 - 包级函数
 func FindProcMap[T comparable, Y any](client *MySQLClient, keyField string, valueField string, procName string, args ...any) (map[T]Y, error) {
-	return findProcMap[T, Y](client, keyField, valueField, procName, args...)
+	return findProcMap[T, Y](client, keyField, valueField, false, procName, args...)
+}
+
+// FindMapUnique 同 FindMap，但键重复时返回错误而不是覆盖，query 为 SQL 字符串或 Builder - 包级函数
+func FindMapUnique[T comparable, Y any](client *MySQLClient, keyField string, valueField string, query any, args ...any) (map[T]Y, error) {
+	sqlQuery, args, err := resolveQuery(query, args)
+	if err != nil {
+		return nil, err
+	}
+	return findMap[T, Y](client, keyField, valueField, true, sqlQuery, args...)
+}
+
+// FindProcMapUnique 同 FindProcMap，但键重复时返回错误而不是覆盖 - 包级函数
+func FindProcMapUnique[T comparable, Y any](client *MySQLClient, keyField string, valueField string, procName string, args ...any) (map[T]Y, error) {
+	return findProcMap[T, Y](client, keyField, valueField, true, procName, args...)
+}
+
+// FindStructs 执行查询并返回结构体切片，T 必须为结构体类型 - 包级函数
+func FindStructs[T any](client *MySQLClient, query string, args ...any) ([]T, error) {
+	var results []T
+	if err := client.Find(&results, query, args...); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// FindProcStructs 执行存储过程并返回结构体切片，T 必须为结构体类型 - 包级函数
+func FindProcStructs[T any](client *MySQLClient, procName string, args ...any) ([]T, error) {
+	var results []T
+	if err := client.FindProc(&results, procName, args...); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// FirstStruct 执行查询并返回一条结构体数据，T 必须为结构体类型 - 包级函数
+func FirstStruct[T any](client *MySQLClient, query string, args ...any) (T, bool, error) {
+	var result T
+	found, err := client.First(&result, query, args...)
+	return result, found, err
+}
+
+// FirstProcStruct 执行存储过程并返回一条结构体数据，T 必须为结构体类型 - 包级函数
+func FirstProcStruct[T any](client *MySQLClient, procName string, args ...any) (T, bool, error) {
+	var result T
+	found, err := client.FirstProc(&result, procName, args...)
+	return result, found, err
 }
 
 // Close 关闭数据库连接
 func (client *MySQLClient) Close() error {
+	if client.multiDB != nil {
+		client.multiDB.Close()
+	}
 	return client.DB.Close()
 }
\ No newline at end of file
@@ -0,0 +1,162 @@
+package smysql
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// skipNonCode 如果 i 处是字符串、带引号的标识符或注释的开头，返回其结束后的位置，否则返回 i
+func skipNonCode(query string, i int) int {
+	switch c := query[i]; c {
+	case '\'', '"', '`':
+		for j := i + 1; j < len(query); j++ {
+			switch query[j] {
+			case '\\':
+				if c != '`' {
+					j++
+				}
+			case c:
+				// 连续两个引号表示转义
+				if j+1 < len(query) && query[j+1] == c {
+					j++
+					continue
+				}
+				return j + 1
+			}
+		}
+		return len(query)
+	case '#':
+		return skipLine(query, i)
+	case '-':
+		if strings.HasPrefix(query[i:], "--") && (i+2 == len(query) || isSpace(query[i+2])) {
+			return skipLine(query, i)
+		}
+	case '/':
+		if strings.HasPrefix(query[i:], "/*") {
+			if end := strings.Index(query[i+2:], "*/"); end >= 0 {
+				return i + 2 + end + 2
+			}
+			return len(query)
+		}
+	}
+	return i
+}
+
+// skipLine 跳到行尾
+func skipLine(query string, i int) int {
+	if end := strings.IndexByte(query[i:], '\n'); end >= 0 {
+		return i + end + 1
+	}
+	return len(query)
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+// isExpandable 判断参数是否需要展开为多个占位符，[]byte 和实现 driver.Valuer 的类型不展开
+func isExpandable(arg any) (reflect.Value, bool) {
+	if arg == nil {
+		return reflect.Value{}, false
+	}
+	if _, ok := arg.(driver.Valuer); ok {
+		return reflect.Value{}, false
+	}
+	v := reflect.ValueOf(arg)
+	if v.Kind() != reflect.Slice || v.Type().Elem().Kind() == reflect.Uint8 {
+		return reflect.Value{}, false
+	}
+	return v, true
+}
+
+// expandArgs 将切片参数对应的 ? 展开为 ?, ?, ?，参数同步展开，空切片展开为 NULL
+// 例如 "WHERE id IN (?)" + []int64{1,2} => "WHERE id IN (?, ?)" + 1, 2
+// 参数会先经过 encodeArgs 转换注册类型
+func expandArgs(query string, args []any) (string, []any, error) {
+	args, err := encodeArgs(args)
+	if err != nil {
+		return "", nil, err
+	}
+
+	needExpand := false
+	for _, arg := range args {
+		if _, ok := isExpandable(arg); ok {
+			needExpand = true
+			break
+		}
+	}
+	if !needExpand {
+		return query, args, nil
+	}
+
+	var sb strings.Builder
+	newArgs := make([]any, 0, len(args))
+	argIndex := 0
+	last := 0
+	for i := 0; i < len(query); {
+		if j := skipNonCode(query, i); j > i {
+			i = j
+			continue
+		}
+		if query[i] != '?' {
+			i++
+			continue
+		}
+		if argIndex >= len(args) {
+			return "", nil, fmt.Errorf("too few arguments for placeholders in query")
+		}
+
+		sb.WriteString(query[last:i])
+		arg := args[argIndex]
+		if v, ok := isExpandable(arg); ok {
+			if v.Len() == 0 {
+				sb.WriteString("NULL")
+			} else {
+				sb.WriteString(strings.TrimSuffix(strings.Repeat("?, ", v.Len()), ", "))
+				for k := 0; k < v.Len(); k++ {
+					elem, err := encodeArg(v.Index(k).Interface())
+					if err != nil {
+						return "", nil, err
+					}
+					newArgs = append(newArgs, elem)
+				}
+			}
+		} else {
+			sb.WriteByte('?')
+			newArgs = append(newArgs, arg)
+		}
+		argIndex++
+		i++
+		last = i
+	}
+	if argIndex != len(args) {
+		return "", nil, fmt.Errorf("expected %d arguments for placeholders, got %d", argIndex, len(args))
+	}
+	sb.WriteString(query[last:])
+	return sb.String(), newArgs, nil
+}
+
+// procQuery 校验存储过程并构建调用语句 CALL `name`(?, ?)，并转换参数
+func (client *MySQLClient) procQuery(procName string, args []any) (string, []any, error) {
+	if err := client.checkProc(context.Background(), procName, len(args)); err != nil {
+		return "", nil, err
+	}
+	args, err := encodeArgs(args)
+	if err != nil {
+		return "", nil, err
+	}
+	placeholders := make([]string, len(args))
+	for i := range placeholders {
+		placeholders[i] = "?"
+	}
+	return procCallSQL(procName, placeholders), args, nil
+}
+
+// procCallSQL 构建 CALL `name`(p1,p2) 语句，params 为各参数位置的占位符或会话变量
+// 名称按标识符转义，schema.proc 会转为 `schema`.`proc`
+func procCallSQL(procName string, params []string) string {
+	return fmt.Sprintf("CALL %s(%s)", quoteIdent(procName), strings.Join(params, ","))
+}
@@ -0,0 +1,78 @@
+package smysql_test
+
+import (
+	"testing"
+
+	"github.com/Xuzan9396/zmysql/smysql"
+)
+
+// TestInClauseExpansion 测试 IN 子句切片参数展开
+func TestInClauseExpansion(t *testing.T) {
+	client, err := getTestClient()
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if err := setupTestData(client); err != nil {
+		t.Fatalf("failed to setup test data: %v", err)
+	}
+
+	t.Run("FindWithSlice", func(t *testing.T) {
+		var cities []CityTest
+		err := client.Find(&cities, "SELECT * FROM cities_test WHERE country_code IN (?) AND flag = ?", []string{"CN", "JP"}, true)
+		if err != nil {
+			t.Fatalf("Find with slice failed: %v", err)
+		}
+		if len(cities) != 5 {
+			t.Errorf("Expected 5 cities, got %d", len(cities))
+		}
+	})
+
+	t.Run("EmptySlice", func(t *testing.T) {
+		var cities []CityTest
+		err := client.Find(&cities, "SELECT * FROM cities_test WHERE id IN (?)", []int64{})
+		if err != nil {
+			t.Fatalf("Find with empty slice failed: %v", err)
+		}
+		if len(cities) != 0 {
+			t.Errorf("Expected 0 cities, got %d", len(cities))
+		}
+	})
+
+	t.Run("GenericHelpers", func(t *testing.T) {
+		ids, err := smysql.FindArray[int64](client, "id", "SELECT id FROM cities_test WHERE name IN (?)", []string{"Tokyo", "Osaka"})
+		if err != nil {
+			t.Fatalf("FindArray with slice failed: %v", err)
+		}
+		if len(ids) != 2 {
+			t.Errorf("Expected 2 ids, got %d", len(ids))
+		}
+
+		count, _, err := client.FirstColInt64("SELECT COUNT(*) FROM cities_test WHERE id IN (?)", ids)
+		if err != nil {
+			t.Fatalf("FirstColInt64 with slice failed: %v", err)
+		}
+		if count != 2 {
+			t.Errorf("Expected count 2, got %d", count)
+		}
+	})
+
+	t.Run("ExecWithSlice", func(t *testing.T) {
+		ok, err := client.Exec("UPDATE cities_test SET flag = ? WHERE name IN (?)", false, []string{"Beijing", "Shanghai"})
+		if err != nil {
+			t.Fatalf("Exec with slice failed: %v", err)
+		}
+		if !ok {
+			t.Error("Expected rows to be affected")
+		}
+	})
+
+	t.Run("PlaceholderMismatch", func(t *testing.T) {
+		var cities []CityTest
+		err := client.Find(&cities, "SELECT * FROM cities_test WHERE id IN (?)", []int64{1}, 2)
+		if err == nil {
+			t.Error("Expected error for placeholder mismatch, but got none")
+		}
+	})
+}
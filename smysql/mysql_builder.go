@@ -0,0 +1,374 @@
+package smysql
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// identPattern 匹配普通标识符或 table.column 形式的标识符
+var identPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_$]*(\.[A-Za-z_][A-Za-z0-9_$]*)?$`)
+
+// Builder 查询构建器，SelectBuilder 实现了该接口，可以直接作为 Find、First、FindArray、FindMap、FindMapUnique 的 query 参数
+type Builder interface {
+	ToSQL() (string, []any, error)
+}
+
+// resolveQuery 将 query 参数转换为 SQL 和参数，query 为 SQL 字符串或 Builder，使用 Builder 时参数由 Builder 生成
+func resolveQuery(query any, args []any) (string, []any, error) {
+	switch q := query.(type) {
+	case string:
+		return q, args, nil
+	case Builder:
+		if len(args) > 0 {
+			return "", nil, fmt.Errorf("args cannot be used with a query builder")
+		}
+		return q.ToSQL()
+	}
+	return "", nil, fmt.Errorf("query must be a string or Builder, got %T", query)
+}
+
+// quoteExpr 为查询列中的简单标识符加反引号，支持 `t.*`、`col AS alias`、`col alias` 形式
+// 其它表达式（函数、聚合等）原样拼接到 SQL 中，不能来自用户输入；包含反引号的表达式无法判断引号边界，返回错误
+func quoteExpr(expr string) (string, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "*" {
+		return expr, nil
+	}
+	if identPattern.MatchString(expr) {
+		return quoteIdent(expr), nil
+	}
+	if strings.HasSuffix(expr, ".*") && identPattern.MatchString(strings.TrimSuffix(expr, ".*")) {
+		return quoteIdent(strings.TrimSuffix(expr, ".*")) + ".*", nil
+	}
+
+	parts := strings.Fields(expr)
+	switch {
+	case len(parts) == 2 && identPattern.MatchString(parts[0]) && identPattern.MatchString(parts[1]) && !strings.Contains(parts[1], "."):
+		return quoteIdent(parts[0]) + " " + quoteIdent(parts[1]), nil
+	case len(parts) == 3 && strings.EqualFold(parts[1], "AS") && identPattern.MatchString(parts[0]) &&
+		identPattern.MatchString(parts[2]) && !strings.Contains(parts[2], "."):
+		return quoteIdent(parts[0]) + " AS " + quoteIdent(parts[2]), nil
+	}
+	if strings.Contains(expr, "`") {
+		return "", fmt.Errorf("select builder: invalid column '%s'", expr)
+	}
+	return expr, nil
+}
+
+// quoteTable 校验并转义表名，只允许 table、schema.table 以及 `table alias`、`table AS alias` 形式
+func quoteTable(table string) (string, error) {
+	parts := strings.Fields(table)
+	switch {
+	case len(parts) == 1 && identPattern.MatchString(parts[0]):
+		return quoteIdent(parts[0]), nil
+	case len(parts) == 2 && identPattern.MatchString(parts[0]) && isAlias(parts[1]):
+		return quoteIdent(parts[0]) + " " + quoteIdent(parts[1]), nil
+	case len(parts) == 3 && strings.EqualFold(parts[1], "AS") && identPattern.MatchString(parts[0]) && isAlias(parts[2]):
+		return quoteIdent(parts[0]) + " AS " + quoteIdent(parts[2]), nil
+	}
+	return "", fmt.Errorf("select builder: invalid table '%s'", table)
+}
+
+// isAlias 判断是否为不含 . 的普通标识符
+func isAlias(name string) bool {
+	return identPattern.MatchString(name) && !strings.Contains(name, ".")
+}
+
+// quoteOrderExpr 校验并转义 ORDER BY、GROUP BY 的列，只允许 col、t.col，allowDirection 为 true 时允许 ASC/DESC 后缀
+// 排序列常来自请求参数，不符合格式时返回错误而不是原样拼接
+func quoteOrderExpr(expr string, allowDirection bool) (string, error) {
+	parts := strings.Fields(expr)
+	if len(parts) == 0 || len(parts) > 2 || !identPattern.MatchString(parts[0]) {
+		return "", fmt.Errorf("select builder: invalid column '%s'", expr)
+	}
+	if len(parts) == 1 {
+		return quoteIdent(parts[0]), nil
+	}
+	direction := strings.ToUpper(parts[1])
+	if !allowDirection || (direction != "ASC" && direction != "DESC") {
+		return "", fmt.Errorf("select builder: invalid column '%s'", expr)
+	}
+	return quoteIdent(parts[0]) + " " + direction, nil
+}
+
+// Cond 查询条件，可通过 Expr、And、Or 组合
+type Cond interface {
+	build() (string, []any)
+}
+
+type exprCond struct {
+	sql  string
+	args []any
+}
+
+func (c exprCond) build() (string, []any) {
+	return c.sql, c.args
+}
+
+type groupCond struct {
+	op    string
+	conds []Cond
+}
+
+func (c groupCond) build() (string, []any) {
+	var parts []string
+	var args []any
+	for _, cond := range c.conds {
+		sql, condArgs := cond.build()
+		if sql == "" {
+			continue
+		}
+		parts = append(parts, "("+sql+")")
+		args = append(args, condArgs...)
+	}
+	return strings.Join(parts, " "+c.op+" "), args
+}
+
+// Expr 创建原生条件表达式，参数使用 ? 占位符
+func Expr(sql string, args ...any) Cond {
+	return exprCond{sql: sql, args: args}
+}
+
+// And 使用 AND 组合多个条件
+func And(conds ...Cond) Cond {
+	return groupCond{op: "AND", conds: conds}
+}
+
+// Or 使用 OR 组合多个条件
+func Or(conds ...Cond) Cond {
+	return groupCond{op: "OR", conds: conds}
+}
+
+type whereItem struct {
+	op   string
+	cond Cond
+}
+
+type joinItem struct {
+	kind  string
+	table string
+	on    string
+	args  []any
+}
+
+// SelectBuilder SELECT 语句构建器
+type SelectBuilder struct {
+	distinct bool
+	columns  []string
+	from     string
+	joins    []joinItem
+	wheres   []whereItem
+	groupBy  []string
+	having   []whereItem
+	orderBy  []string
+	limit    int
+	offset   int
+}
+
+// Select 创建 SELECT 构建器，未指定列时查询 *，列中的函数、聚合等表达式原样拼接，不能来自用户输入，包含反引号的表达式会使 ToSQL 返回错误
+func Select(columns ...string) *SelectBuilder {
+	return &SelectBuilder{columns: columns, limit: -1, offset: -1}
+}
+
+// Distinct 使用 SELECT DISTINCT
+func (b *SelectBuilder) Distinct() *SelectBuilder {
+	b.distinct = true
+	return b
+}
+
+// From 设置查询的表，只允许 table、schema.table 以及 `table alias`、`table AS alias` 形式，否则 ToSQL 返回错误
+func (b *SelectBuilder) From(table string) *SelectBuilder {
+	b.from = table
+	return b
+}
+
+// Join 添加 INNER JOIN，table 的格式同 From
+func (b *SelectBuilder) Join(table string, on string, args ...any) *SelectBuilder {
+	b.joins = append(b.joins, joinItem{kind: "JOIN", table: table, on: on, args: args})
+	return b
+}
+
+// LeftJoin 添加 LEFT JOIN
+func (b *SelectBuilder) LeftJoin(table string, on string, args ...any) *SelectBuilder {
+	b.joins = append(b.joins, joinItem{kind: "LEFT JOIN", table: table, on: on, args: args})
+	return b
+}
+
+// RightJoin 添加 RIGHT JOIN
+func (b *SelectBuilder) RightJoin(table string, on string, args ...any) *SelectBuilder {
+	b.joins = append(b.joins, joinItem{kind: "RIGHT JOIN", table: table, on: on, args: args})
+	return b
+}
+
+// Where 添加 AND 条件
+func (b *SelectBuilder) Where(sql string, args ...any) *SelectBuilder {
+	return b.WhereCond(Expr(sql, args...))
+}
+
+// OrWhere 添加 OR 条件
+func (b *SelectBuilder) OrWhere(sql string, args ...any) *SelectBuilder {
+	return b.OrWhereCond(Expr(sql, args...))
+}
+
+// WhereCond 添加 AND 条件组
+func (b *SelectBuilder) WhereCond(cond Cond) *SelectBuilder {
+	b.wheres = append(b.wheres, whereItem{op: "AND", cond: cond})
+	return b
+}
+
+// OrWhereCond 添加 OR 条件组
+func (b *SelectBuilder) OrWhereCond(cond Cond) *SelectBuilder {
+	b.wheres = append(b.wheres, whereItem{op: "OR", cond: cond})
+	return b
+}
+
+// GroupBy 设置 GROUP BY，只允许 col 或 t.col 形式，否则 ToSQL 返回错误
+func (b *SelectBuilder) GroupBy(columns ...string) *SelectBuilder {
+	b.groupBy = append(b.groupBy, columns...)
+	return b
+}
+
+// Having 添加 HAVING 条件
+func (b *SelectBuilder) Having(sql string, args ...any) *SelectBuilder {
+	b.having = append(b.having, whereItem{op: "AND", cond: Expr(sql, args...)})
+	return b
+}
+
+// OrderBy 设置排序，如 OrderBy("id DESC", "name")，只允许列名加可选的 ASC/DESC，否则 ToSQL 返回错误
+func (b *SelectBuilder) OrderBy(exprs ...string) *SelectBuilder {
+	b.orderBy = append(b.orderBy, exprs...)
+	return b
+}
+
+// Limit 设置 LIMIT
+func (b *SelectBuilder) Limit(n int) *SelectBuilder {
+	b.limit = n
+	return b
+}
+
+// Offset 设置 OFFSET
+func (b *SelectBuilder) Offset(n int) *SelectBuilder {
+	b.offset = n
+	return b
+}
+
+// whereGroup 将条件列表作为一个整体条件
+type whereGroup []whereItem
+
+func (g whereGroup) build() (string, []any) {
+	return buildWhere(g)
+}
+
+// buildWhere 拼接条件列表
+func buildWhere(items []whereItem) (string, []any) {
+	var sb strings.Builder
+	var args []any
+	for _, item := range items {
+		sql, condArgs := item.cond.build()
+		if sql == "" {
+			continue
+		}
+		if sb.Len() > 0 {
+			sb.WriteString(" " + item.op + " ")
+		}
+		sb.WriteString("(" + sql + ")")
+		args = append(args, condArgs...)
+	}
+	return sb.String(), args
+}
+
+// ToSQL 生成 SQL 和参数
+func (b *SelectBuilder) ToSQL() (string, []any, error) {
+	if b == nil {
+		return "", nil, fmt.Errorf("select builder cannot be nil")
+	}
+	if b.from == "" {
+		return "", nil, fmt.Errorf("select builder: table cannot be empty")
+	}
+	if b.offset >= 0 && b.limit < 0 {
+		return "", nil, fmt.Errorf("select builder: offset requires limit")
+	}
+
+	var sb strings.Builder
+	var args []any
+
+	sb.WriteString("SELECT ")
+	if b.distinct {
+		sb.WriteString("DISTINCT ")
+	}
+	if len(b.columns) == 0 {
+		sb.WriteString("*")
+	} else {
+		columns := make([]string, len(b.columns))
+		for i, col := range b.columns {
+			column, err := quoteExpr(col)
+			if err != nil {
+				return "", nil, err
+			}
+			columns[i] = column
+		}
+		sb.WriteString(strings.Join(columns, ", "))
+	}
+
+	from, err := quoteTable(b.from)
+	if err != nil {
+		return "", nil, err
+	}
+	sb.WriteString(" FROM " + from)
+
+	for _, join := range b.joins {
+		table, err := quoteTable(join.table)
+		if err != nil {
+			return "", nil, err
+		}
+		sb.WriteString(" " + join.kind + " " + table)
+		if join.on != "" {
+			sb.WriteString(" ON " + join.on)
+			args = append(args, join.args...)
+		}
+	}
+
+	if where, whereArgs := buildWhere(b.wheres); where != "" {
+		sb.WriteString(" WHERE " + where)
+		args = append(args, whereArgs...)
+	}
+
+	if len(b.groupBy) > 0 {
+		columns := make([]string, len(b.groupBy))
+		for i, col := range b.groupBy {
+			column, err := quoteOrderExpr(col, false)
+			if err != nil {
+				return "", nil, err
+			}
+			columns[i] = column
+		}
+		sb.WriteString(" GROUP BY " + strings.Join(columns, ", "))
+	}
+
+	if having, havingArgs := buildWhere(b.having); having != "" {
+		sb.WriteString(" HAVING " + having)
+		args = append(args, havingArgs...)
+	}
+
+	if len(b.orderBy) > 0 {
+		exprs := make([]string, len(b.orderBy))
+		for i, expr := range b.orderBy {
+			column, err := quoteOrderExpr(expr, true)
+			if err != nil {
+				return "", nil, err
+			}
+			exprs[i] = column
+		}
+		sb.WriteString(" ORDER BY " + strings.Join(exprs, ", "))
+	}
+
+	if b.limit >= 0 {
+		sb.WriteString(fmt.Sprintf(" LIMIT %d", b.limit))
+		if b.offset >= 0 {
+			sb.WriteString(fmt.Sprintf(" OFFSET %d", b.offset))
+		}
+	}
+
+	return sb.String(), args, nil
+}
@@ -0,0 +1,201 @@
+package smysql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Xuzan9396/zmysql/smysql"
+)
+
+// TestSelectBuilderToSQL 测试构建器生成的 SQL
+func TestSelectBuilderToSQL(t *testing.T) {
+	tests := []struct {
+		name     string
+		builder  *smysql.SelectBuilder
+		wantSQL  string
+		wantArgs []any
+	}{
+		{
+			name:     "Basic",
+			builder:  smysql.Select("id", "name").From("cities_test").Where("country_code = ?", "CN").OrderBy("id DESC").Limit(10),
+			wantSQL:  "SELECT `id`, `name` FROM `cities_test` WHERE (country_code = ?) ORDER BY `id` DESC LIMIT 10",
+			wantArgs: []any{"CN"},
+		},
+		{
+			name:     "SelectAll",
+			builder:  smysql.Select().From("cities_test"),
+			wantSQL:  "SELECT * FROM `cities_test`",
+			wantArgs: nil,
+		},
+		{
+			name: "AndOrGroups",
+			builder: smysql.Select("id").From("cities_test").
+				Where("flag = ?", true).
+				WhereCond(smysql.Or(smysql.Expr("country_code = ?", "CN"), smysql.Expr("country_code = ?", "JP"))).
+				OrWhere("id = ?", 1),
+			wantSQL:  "SELECT `id` FROM `cities_test` WHERE (flag = ?) AND ((country_code = ?) OR (country_code = ?)) OR (id = ?)",
+			wantArgs: []any{true, "CN", "JP", 1},
+		},
+		{
+			name: "JoinAndAlias",
+			builder: smysql.Select("c.id", "c.name AS city_name", "s.*", "COUNT(*) AS total").
+				From("cities_test c").
+				LeftJoin("states s", "s.id = c.state_id AND s.flag = ?", 1).
+				Where("c.country_id = ?", 1).
+				GroupBy("c.id").
+				Having("COUNT(*) > ?", 0).
+				Limit(5).Offset(10),
+			wantSQL: "SELECT `c`.`id`, `c`.`name` AS `city_name`, `s`.*, COUNT(*) AS total FROM `cities_test` `c` " +
+				"LEFT JOIN `states` `s` ON s.id = c.state_id AND s.flag = ? WHERE (c.country_id = ?) GROUP BY `c`.`id` " +
+				"HAVING (COUNT(*) > ?) LIMIT 5 OFFSET 10",
+			wantArgs: []any{1, 1, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args, err := tt.builder.ToSQL()
+			if err != nil {
+				t.Fatalf("ToSQL failed: %v", err)
+			}
+			if sql != tt.wantSQL {
+				t.Errorf("Expected SQL:\n%s\ngot:\n%s", tt.wantSQL, sql)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("Expected args %v, got %v", tt.wantArgs, args)
+			}
+		})
+	}
+
+	t.Run("MissingTable", func(t *testing.T) {
+		if _, _, err := smysql.Select("id").ToSQL(); err == nil {
+			t.Error("Expected error for missing table, but got none")
+		}
+	})
+
+	t.Run("RejectBacktickColumn", func(t *testing.T) {
+		for _, col := range []string{"na`me", "id` FROM mysql.user -- ", "COUNT(`id`)"} {
+			if sql, _, err := smysql.Select(col).From("cities_test").ToSQL(); err == nil {
+				t.Errorf("Expected error for column %q, got %s", col, sql)
+			}
+		}
+	})
+
+	t.Run("RejectInvalidTable", func(t *testing.T) {
+		invalid := []*smysql.SelectBuilder{
+			smysql.Select().From("t; DROP TABLE x"),
+			smysql.Select().From("cities_test c WHERE 1=1"),
+			smysql.Select().From("(SELECT 1) t"),
+			smysql.Select().From("a.b.c"),
+			smysql.Select().From("cities_test c.x"),
+			smysql.Select().From("cities_test").Join("states s ON 1=1 --", "s.id = c.state_id"),
+			smysql.Select().From("cities_test").LeftJoin("st`ates", "1"),
+		}
+		for _, b := range invalid {
+			if sql, _, err := b.ToSQL(); err == nil {
+				t.Errorf("Expected error for invalid table, got %s", sql)
+			}
+		}
+
+		sql, _, err := smysql.Select().From("weather.cities_test AS c").RightJoin("states", "states.id = c.state_id").ToSQL()
+		if err != nil {
+			t.Fatalf("ToSQL failed: %v", err)
+		}
+		if want := "SELECT * FROM `weather`.`cities_test` AS `c` RIGHT JOIN `states` ON states.id = c.state_id"; sql != want {
+			t.Errorf("Expected SQL:\n%s\ngot:\n%s", want, sql)
+		}
+	})
+
+	t.Run("RejectInvalidOrderAndGroup", func(t *testing.T) {
+		invalid := []*smysql.SelectBuilder{
+			smysql.Select().From("cities_test").OrderBy("id; DROP TABLE cities_test"),
+			smysql.Select().From("cities_test").OrderBy("(SELECT SLEEP(1))"),
+			smysql.Select().From("cities_test").OrderBy("id DESC, name"),
+			smysql.Select().From("cities_test").OrderBy("id SIDEWAYS"),
+			smysql.Select().From("cities_test").GroupBy("id DESC"),
+			smysql.Select().From("cities_test").GroupBy("LENGTH(name)"),
+		}
+		for _, b := range invalid {
+			if sql, _, err := b.ToSQL(); err == nil {
+				t.Errorf("Expected error for invalid column, got %s", sql)
+			}
+		}
+
+		sql, _, err := smysql.Select().From("cities_test c").GroupBy("c.country_id").OrderBy("c.country_id desc").ToSQL()
+		if err != nil {
+			t.Fatalf("ToSQL failed: %v", err)
+		}
+		if want := "SELECT * FROM `cities_test` `c` GROUP BY `c`.`country_id` ORDER BY `c`.`country_id` DESC"; sql != want {
+			t.Errorf("Expected SQL:\n%s\ngot:\n%s", want, sql)
+		}
+	})
+}
+
+// TestSelectBuilderQuery 测试构建器与查询方法配合使用
+func TestSelectBuilderQuery(t *testing.T) {
+	client, err := getTestClient()
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if err := setupTestData(client); err != nil {
+		t.Fatalf("failed to setup test data: %v", err)
+	}
+
+	t.Run("Find", func(t *testing.T) {
+		var cities []CityTest
+		err := client.Find(&cities, smysql.Select().From("cities_test").Where("country_code = ?", "CN").OrderBy("id"))
+		if err != nil {
+			t.Fatalf("Find failed: %v", err)
+		}
+		if len(cities) != 4 {
+			t.Errorf("Expected 4 cities, got %d", len(cities))
+		}
+	})
+
+	t.Run("First", func(t *testing.T) {
+		var city CityTest
+		found, err := client.First(&city, smysql.Select().From("cities_test").Where("name = ?", "Tokyo"))
+		if err != nil {
+			t.Fatalf("First failed: %v", err)
+		}
+		if !found || city.CountryCode != "JP" {
+			t.Errorf("Expected Tokyo in JP, got found=%v city=%+v", found, city)
+		}
+	})
+
+	t.Run("FindArrayAndMap", func(t *testing.T) {
+		names, err := smysql.FindArray[string](client, "name",
+			smysql.Select("name").From("cities_test").Where("country_id = ?", 2).OrderBy("name"))
+		if err != nil {
+			t.Fatalf("FindArray failed: %v", err)
+		}
+		if !reflect.DeepEqual(names, []string{"Osaka", "Tokyo"}) {
+			t.Errorf("Expected [Osaka Tokyo], got %v", names)
+		}
+
+		cityMap, err := smysql.FindMap[int64, string](client, "id", "name",
+			smysql.Select("id", "name").From("cities_test").Where("country_id = ?", 1))
+		if err != nil {
+			t.Fatalf("FindMap failed: %v", err)
+		}
+		if len(cityMap) != 4 {
+			t.Errorf("Expected 4 entries, got %d", len(cityMap))
+		}
+	})
+
+	t.Run("RejectInvalidQuery", func(t *testing.T) {
+		var cities []CityTest
+		if err := client.Find(&cities, smysql.Select().From("cities_test"), 1); err == nil {
+			t.Error("Expected error for args with a builder, but got none")
+		}
+		if err := client.Find(&cities, 42); err == nil {
+			t.Error("Expected error for unsupported query type, but got none")
+		}
+		var nilBuilder *smysql.SelectBuilder
+		if _, err := client.First(&CityTest{}, nilBuilder); err == nil {
+			t.Error("Expected error for nil builder, but got none")
+		}
+	})
+}
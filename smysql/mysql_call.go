@@ -0,0 +1,176 @@
+package smysql
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+type paramMode int8
+
+const (
+	paramIn paramMode = iota
+	paramOut
+	paramInOut
+	paramResult
+)
+
+// ProcParam CallProc 的参数，通过 In、Out、InOut、ResultSet 创建
+type ProcParam struct {
+	mode  paramMode
+	value any // IN 参数的值，其他为目标指针
+}
+
+// In 输入参数
+func In(value any) ProcParam {
+	return ProcParam{mode: paramIn, value: value}
+}
+
+// Out 输出参数，dest 为指针，调用结束后写入 OUT 参数的值，NULL 时为零值
+func Out(dest any) ProcParam {
+	return ProcParam{mode: paramOut, value: dest}
+}
+
+// InOut 输入输出参数，dest 为指针，其当前值作为输入，调用结束后写入返回的值
+func InOut(dest any) ProcParam {
+	return ProcParam{mode: paramInOut, value: dest}
+}
+
+// ResultSet 接收存储过程返回的结果集，dest 为结构体切片指针或结构体指针，按出现顺序依次对应
+// 不占用存储过程的参数位置，未提供 ResultSet 的结果集会被跳过
+func ResultSet(dest any) ProcParam {
+	return ProcParam{mode: paramResult, value: dest}
+}
+
+// CallProc 调用存储过程，支持 IN、OUT、INOUT 参数和返回的结果集
+// OUT/INOUT 参数通过同一连接上的会话变量传递：SET @v = ?; CALL proc(?, @v); SELECT @v
+//
+//	client.CallProc(ctx, "proc", smysql.In(x), smysql.Out(&total), smysql.InOut(&counter), smysql.ResultSet(&rows))
+func (client *MySQLClient) CallProc(ctx context.Context, procName string, params ...ProcParam) error {
+	var (
+		placeholders []string
+		callArgs     []any
+		setVars      []string
+		setArgs      []any
+		outVars      []string
+		outDest      []reflect.Value
+		resultDest   []any
+	)
+	for i, param := range params {
+		if param.mode == paramResult {
+			resultDest = append(resultDest, param.value)
+			continue
+		}
+		if param.mode == paramIn {
+			value, err := encodeArg(param.value)
+			if err != nil {
+				return err
+			}
+			placeholders = append(placeholders, "?")
+			callArgs = append(callArgs, value)
+			continue
+		}
+
+		dest := reflect.ValueOf(param.value)
+		if dest.Kind() != reflect.Ptr || dest.IsNil() {
+			return fmt.Errorf("param %d must be a non-nil pointer", i)
+		}
+		// 参数位置唯一，使用同一连接，不会与其他调用冲突
+		name := fmt.Sprintf("@_zmysql_p%d", i)
+		if param.mode == paramInOut {
+			value, err := encodeArg(dest.Elem().Interface())
+			if err != nil {
+				return err
+			}
+			setVars = append(setVars, name+" = ?")
+			setArgs = append(setArgs, value)
+		} else {
+			// 清空上次调用残留在连接上的值
+			setVars = append(setVars, name+" = NULL")
+		}
+		placeholders = append(placeholders, name)
+		outVars = append(outVars, name)
+		outDest = append(outDest, dest.Elem())
+	}
+
+	if err := client.checkProc(ctx, procName, len(placeholders)); err != nil {
+		return err
+	}
+
+	// 会话变量只在同一连接上有效
+	conn, err := client.DB.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get connection: %v", err)
+	}
+	defer conn.Close()
+
+	if len(setVars) > 0 {
+		query := "SET " + strings.Join(setVars, ", ")
+		client.debugLog(query, setArgs...)
+		if _, err := conn.ExecContext(ctx, query, setArgs...); err != nil {
+			return fmt.Errorf("failed to set out parameters: %v", err)
+		}
+	}
+
+	query := procCallSQL(procName, placeholders)
+	client.debugLog(query, callArgs...)
+	stmt, err := conn.PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("failed to prepare query: %v", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, callArgs...)
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %v", err)
+	}
+	for index := 0; ; index++ {
+		if index < len(resultDest) {
+			if err := client.scanResultSet(rows, resultDest[index], index); err != nil {
+				rows.Close()
+				return err
+			}
+		}
+		if !rows.NextResultSet() {
+			break
+		}
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return fmt.Errorf("rows iteration error: %v", err)
+	}
+	rows.Close()
+
+	if len(outVars) == 0 {
+		return nil
+	}
+
+	query = "SELECT " + strings.Join(outVars, ", ")
+	client.debugLog(query)
+	outRows, err := conn.QueryContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("failed to query out parameters: %v", err)
+	}
+	defer outRows.Close()
+
+	if !outRows.Next() {
+		if err := outRows.Err(); err != nil {
+			return fmt.Errorf("failed to query out parameters: %v", err)
+		}
+		return fmt.Errorf("no out parameters returned")
+	}
+	scanDest := make([]any, len(outDest))
+	for i, dest := range outDest {
+		scanDest[i] = client.createNullScanner(dest.Type())
+	}
+	if err := outRows.Scan(scanDest...); err != nil {
+		return fmt.Errorf("failed to scan out parameters: %v", err)
+	}
+	for i, dest := range outDest {
+		if err := client.setFieldFromNullScanner(dest, scanDest[i], dest.Type()); err != nil {
+			return fmt.Errorf("failed to set out parameter %s: %v", outVars[i], err)
+		}
+	}
+	return outRows.Err()
+}
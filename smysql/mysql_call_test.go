@@ -0,0 +1,72 @@
+package smysql_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Xuzan9396/zmysql/smysql"
+)
+
+// TestCallProc 测试带 OUT、INOUT 参数的存储过程调用
+func TestCallProc(t *testing.T) {
+	client, err := getTestClient()
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if err := setupTestData(client); err != nil {
+		t.Fatalf("failed to setup test data: %v", err)
+	}
+
+	if _, err := client.DB.Exec("DROP PROCEDURE IF EXISTS cities_count_test"); err != nil {
+		t.Fatalf("failed to drop procedure: %v", err)
+	}
+	_, err = client.DB.Exec(`CREATE PROCEDURE cities_count_test(IN p_country CHAR(2), OUT p_total INT, INOUT p_counter INT)
+BEGIN
+	SELECT COUNT(*) INTO p_total FROM cities_test WHERE country_code = p_country;
+	SET p_counter = p_counter + p_total;
+	SELECT * FROM cities_test WHERE country_code = p_country;
+END`)
+	if err != nil {
+		t.Fatalf("failed to create procedure: %v", err)
+	}
+	defer client.DB.Exec("DROP PROCEDURE IF EXISTS cities_count_test")
+
+	ctx := context.Background()
+
+	t.Run("OutAndInOut", func(t *testing.T) {
+		var total int64
+		counter := 10
+		var cities []CityTest
+		err := client.CallProc(ctx, "cities_count_test",
+			smysql.In("CN"), smysql.Out(&total), smysql.InOut(&counter), smysql.ResultSet(&cities))
+		if err != nil {
+			t.Fatalf("CallProc failed: %v", err)
+		}
+		if total != int64(len(cities)) || total == 0 {
+			t.Errorf("Expected total %d to match %d cities", total, len(cities))
+		}
+		if counter != 10+int(total) {
+			t.Errorf("Expected counter %d, got %d", 10+total, counter)
+		}
+	})
+
+	t.Run("WithoutResultSet", func(t *testing.T) {
+		var total int64
+		counter := 0
+		if err := client.CallProc(ctx, "cities_count_test", smysql.In("XX"), smysql.Out(&total), smysql.InOut(&counter)); err != nil {
+			t.Fatalf("CallProc failed: %v", err)
+		}
+		if total != 0 || counter != 0 {
+			t.Errorf("Expected zero total and counter, got %d, %d", total, counter)
+		}
+	})
+
+	t.Run("RejectNonPointer", func(t *testing.T) {
+		var total int64
+		if err := client.CallProc(ctx, "cities_count_test", smysql.In("CN"), smysql.Out(total), smysql.InOut(nil)); err == nil {
+			t.Error("Expected error for non-pointer out parameter, but got none")
+		}
+	})
+}
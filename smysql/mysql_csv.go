@@ -0,0 +1,146 @@
+package smysql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// nullEscape LOAD DATA 默认的 NULL 表示
+const nullEscape = `\N`
+
+// CSVOptions ExportCSV 的输出选项
+type CSVOptions struct {
+	Header    bool   // 第一行输出列名
+	Delimiter rune   // 字段分隔符，默认 ','，TSV 使用 '\t'
+	NullAs    string // NULL 的输出，默认空字符串；为 `\N` 时值中的反斜杠转义为 `\\`，文件可以通过 LOAD DATA 导入
+}
+
+// ExportCSV 执行查询并将结果按 CSV 逐行写入 w，不在内存中保存整个结果集
+// 时间输出为 MySQL 格式 2006-01-02 15:04:05，DECIMAL、JSON 等保持数据库返回的文本
+// ctx 取消或写入失败时立即停止并返回错误
+func (client *MySQLClient) ExportCSV(ctx context.Context, w io.Writer, opts CSVOptions, query string, args ...any) error {
+	return client.streamRows(ctx, query, args, func(rows *sql.Rows) error {
+		return client.writeCSV(ctx, w, rows, opts)
+	})
+}
+
+// writeCSV 逐行格式化并写入 w
+func (client *MySQLClient) writeCSV(ctx context.Context, w io.Writer, rows *sql.Rows, opts CSVOptions) error {
+	columns, err := rows.Columns()
+	if err != nil {
+		return fmt.Errorf("failed to get columns: %v", err)
+	}
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return fmt.Errorf("failed to get column types: %v", err)
+	}
+	kinds := make([]jsonKind, len(columns))
+	for i, columnType := range columnTypes {
+		kinds[i] = columnKind(columnType)
+	}
+
+	writer := csv.NewWriter(w)
+	if opts.Delimiter != 0 {
+		writer.Comma = opts.Delimiter
+	}
+	if opts.Header {
+		if err := writer.Write(columns); err != nil {
+			return fmt.Errorf("failed to write: %v", err)
+		}
+	}
+
+	values := make([]any, len(columns))
+	pointers := make([]any, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	record := make([]string, len(columns))
+
+	for rows.Next() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return fmt.Errorf("failed to scan row: %v", err)
+		}
+		for i, value := range values {
+			record[i] = csvValue(value, kinds[i], opts.NullAs)
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write: %v", err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("rows iteration error: %v", err)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to write: %v", err)
+	}
+	return nil
+}
+
+// csvValue 将驱动返回的值格式化为 CSV 字段
+func csvValue(value any, kind jsonKind, nullAs string) string {
+	if value == nil {
+		return nullAs
+	}
+
+	var s string
+	switch v := value.(type) {
+	case time.Time:
+		switch {
+		case kind == jsonDate && v.IsZero():
+			s = "0000-00-00"
+		case kind == jsonDate:
+			s = v.Format(time.DateOnly)
+		case v.IsZero():
+			s = "0000-00-00 00:00:00"
+		default:
+			s = v.Format("2006-01-02 15:04:05.999999")
+		}
+	case []byte:
+		if kind == jsonBit {
+			var n uint64
+			for _, c := range v {
+				n = n<<8 | uint64(c)
+			}
+			s = strconv.FormatUint(n, 10)
+		} else {
+			s = string(v)
+		}
+	case string:
+		s = v
+	case int64:
+		s = strconv.FormatInt(v, 10)
+	case uint64:
+		s = strconv.FormatUint(v, 10)
+	case float64:
+		s = strconv.FormatFloat(v, 'g', -1, 64)
+	case float32:
+		s = strconv.FormatFloat(float64(v), 'g', -1, 32)
+	case bool:
+		s = "0"
+		if v {
+			s = "1"
+		}
+	default:
+		s = fmt.Sprint(v)
+	}
+
+	// LOAD DATA 默认以反斜杠为转义符，值中的反斜杠需要转义，避免与 \N 混淆
+	if nullAs == nullEscape {
+		s = strings.ReplaceAll(s, `\`, `\\`)
+	}
+	return s
+}
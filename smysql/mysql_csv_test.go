@@ -0,0 +1,67 @@
+package smysql_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/Xuzan9396/zmysql/smysql"
+)
+
+// TestExportCSV 测试 CSV 和 TSV 导出
+func TestExportCSV(t *testing.T) {
+	client, err := getTestClient()
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if err := setupTestData(client); err != nil {
+		t.Fatalf("failed to setup test data: %v", err)
+	}
+
+	query := "SELECT id, name, latitude, created_at, wikiDataId FROM cities_test WHERE country_code = ? ORDER BY id"
+
+	t.Run("CSV", func(t *testing.T) {
+		if _, err := client.Exec("UPDATE cities_test SET name = ?, wikiDataId = NULL WHERE name = ?", `Osaka, "JP"`, "Osaka"); err != nil {
+			t.Fatalf("Exec failed: %v", err)
+		}
+
+		var buf bytes.Buffer
+		if err := client.ExportCSV(context.Background(), &buf, smysql.CSVOptions{Header: true}, query, "JP"); err != nil {
+			t.Fatalf("ExportCSV failed: %v", err)
+		}
+
+		records, err := csv.NewReader(&buf).ReadAll()
+		if err != nil {
+			t.Fatalf("Failed to read CSV: %v", err)
+		}
+		if len(records) != 3 {
+			t.Fatalf("Expected header and 2 rows, got %d", len(records))
+		}
+		if strings.Join(records[0], ",") != "id,name,latitude,created_at,wikiDataId" {
+			t.Errorf("Unexpected header: %v", records[0])
+		}
+		osaka := records[2]
+		if osaka[1] != `Osaka, "JP"` || osaka[2] != "34.69370000" || osaka[3] != "2014-01-01 06:31:01" || osaka[4] != "" {
+			t.Errorf("Unexpected row: %q", osaka)
+		}
+	})
+
+	t.Run("TSVWithNull", func(t *testing.T) {
+		if _, err := client.Exec("UPDATE cities_test SET state_code = ? WHERE name = ?", `T\K`, "Tokyo"); err != nil {
+			t.Fatalf("Exec failed: %v", err)
+		}
+
+		var buf bytes.Buffer
+		opts := smysql.CSVOptions{Delimiter: '\t', NullAs: `\N`}
+		if err := client.ExportCSV(context.Background(), &buf, opts, "SELECT state_code, NULL AS empty FROM cities_test WHERE name = ?", "Tokyo"); err != nil {
+			t.Fatalf("ExportCSV failed: %v", err)
+		}
+		if buf.String() != "T\\\\K\t\\N\n" {
+			t.Errorf("Unexpected TSV output: %q", buf.String())
+		}
+	})
+}
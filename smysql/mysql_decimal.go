@@ -0,0 +1,100 @@
+package smysql
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math/big"
+)
+
+type DECIMAL_MODE int8
+
+const (
+	DECIMAL_AS_NUMBER DECIMAL_MODE = iota // 默认，输出为 JSON 数字，不经过 float64，精度不丢失
+	DECIMAL_AS_STRING                     // 输出为 JSON 字符串
+)
+
+// maxDecimalScale MySQL DECIMAL 支持的最大小数位数
+const maxDecimalScale = 30
+
+func init() {
+	// big.Rat 作为内置的精确小数类型，DECIMAL 列可以直接映射到 big.Rat 或 *big.Rat 字段
+	RegisterType[big.Rat](decodeRat, func(r big.Rat) (driver.Value, error) {
+		return ratString(&r), nil
+	})
+}
+
+// WithDecimalMode 设置 ExecByte、ExecProcByte 中 DECIMAL 列的 JSON 输出方式，默认 DECIMAL_AS_NUMBER
+func WithDecimalMode(mode DECIMAL_MODE) func(*MySQLClient) {
+	return func(client *MySQLClient) {
+		client.decimalMode = mode
+	}
+}
+
+// decodeRat 将驱动返回的 DECIMAL 值转换为 big.Rat
+func decodeRat(src any) (big.Rat, error) {
+	var r big.Rat
+	switch v := src.(type) {
+	case []byte:
+		if _, ok := r.SetString(string(v)); !ok {
+			return r, fmt.Errorf("invalid decimal value '%s'", v)
+		}
+	case string:
+		if _, ok := r.SetString(v); !ok {
+			return r, fmt.Errorf("invalid decimal value '%s'", v)
+		}
+	case int64:
+		r.SetInt64(v)
+	case float64:
+		if r.SetFloat64(v) == nil {
+			return r, fmt.Errorf("invalid decimal value %v", v)
+		}
+	default:
+		return r, fmt.Errorf("cannot convert %T to big.Rat", src)
+	}
+	return r, nil
+}
+
+// ratString 将 big.Rat 格式化为十进制字符串，有限小数精确输出，无限小数保留 MySQL 支持的最大位数
+func ratString(r *big.Rat) string {
+	if r.IsInt() {
+		return r.Num().String()
+	}
+
+	// 分母只含因子 2 和 5 时是有限小数，所需位数为两者指数的较大值
+	denom := new(big.Int).Set(r.Denom())
+	scale := 0
+	for _, p := range []int64{2, 5} {
+		n, mod, factor := 0, new(big.Int), big.NewInt(p)
+		for {
+			q, m := new(big.Int).QuoRem(denom, factor, mod)
+			if m.Sign() != 0 {
+				break
+			}
+			denom, n = q, n+1
+		}
+		scale = max(scale, n)
+	}
+	if denom.Cmp(big.NewInt(1)) != 0 {
+		scale = maxDecimalScale
+	}
+	return r.FloatString(scale)
+}
+
+// decimalJSONValue 按 mode 转换 DECIMAL 列的值，用于 JSON 输出，默认输出为不经过 float64 的 JSON 数字
+func decimalJSONValue(value any, mode DECIMAL_MODE) any {
+	var s string
+	switch v := value.(type) {
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	default:
+		return value
+	}
+
+	if mode == DECIMAL_AS_STRING {
+		return s
+	}
+	return json.Number(s)
+}
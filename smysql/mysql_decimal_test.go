@@ -0,0 +1,78 @@
+package smysql_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/Xuzan9396/zmysql/smysql"
+)
+
+// TestDecimalMapping 测试 DECIMAL 列精确映射
+func TestDecimalMapping(t *testing.T) {
+	client, err := getTestClient()
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if err := setupTestData(client); err != nil {
+		t.Fatalf("failed to setup test data: %v", err)
+	}
+
+	type City struct {
+		ID        uint     `db:"id"`
+		Latitude  string   `db:"latitude"`
+		Longitude *big.Rat `db:"longitude"`
+		Exact     big.Rat  `db:"exact"`
+	}
+
+	t.Run("StringAndRat", func(t *testing.T) {
+		var city City
+		found, err := client.First(&city, "SELECT id, latitude, longitude, CAST(0.1 AS DECIMAL(20,18)) AS exact FROM cities_test WHERE name = ?", "Beijing")
+		if err != nil || !found {
+			t.Fatalf("First failed: found=%v err=%v", found, err)
+		}
+		if city.Latitude != "39.90420000" {
+			t.Errorf("Expected latitude 39.90420000, got %s", city.Latitude)
+		}
+		if city.Longitude == nil || city.Longitude.FloatString(4) != "116.4074" {
+			t.Errorf("Unexpected longitude: %v", city.Longitude)
+		}
+		if city.Exact.Cmp(big.NewRat(1, 10)) != 0 {
+			t.Errorf("Expected exact 1/10, got %s", city.Exact.String())
+		}
+	})
+
+	t.Run("RatArg", func(t *testing.T) {
+		lat, _ := new(big.Rat).SetString("39.9042")
+		var name string
+		found, err := client.FirstCol(&name, "SELECT name FROM cities_test WHERE latitude = ?", lat)
+		if err != nil || !found {
+			t.Fatalf("FirstCol failed: found=%v err=%v", found, err)
+		}
+		if name != "Beijing" {
+			t.Errorf("Expected Beijing, got %s", name)
+		}
+	})
+
+	t.Run("ExecByteDecimalMode", func(t *testing.T) {
+		numberClient, err := getTestClient(smysql.WithDecimalMode(smysql.DECIMAL_AS_NUMBER))
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+		defer numberClient.Close()
+
+		data, err := numberClient.ExecByte("SELECT latitude FROM cities_test WHERE name = ?", smysql.HAS_ONE, "Beijing")
+		if err != nil {
+			t.Fatalf("ExecByte failed: %v", err)
+		}
+		var result map[string]json.Number
+		if err := json.Unmarshal(data, &result); err != nil {
+			t.Fatalf("Failed to unmarshal result: %v", err)
+		}
+		if result["latitude"] != "39.90420000" {
+			t.Errorf("Expected exact decimal 39.90420000, got %s", data)
+		}
+	})
+}
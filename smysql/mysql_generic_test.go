@@ -0,0 +1,62 @@
+package smysql_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Xuzan9396/zmysql/smysql"
+)
+
+// UserID 自定义的命名类型
+type UserID int64
+
+// TestWideGenerics 测试泛型函数支持更多类型以及返回结构体值
+func TestWideGenerics(t *testing.T) {
+	client, err := getTestClient()
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if err := setupTestData(client); err != nil {
+		t.Fatalf("failed to setup test data: %v", err)
+	}
+
+	t.Run("ScalarTypes", func(t *testing.T) {
+		lats, err := smysql.FindArray[float64](client, "latitude", "SELECT latitude FROM cities_test WHERE country_id = ?", 1)
+		if err != nil || len(lats) != 4 {
+			t.Errorf("FindArray[float64] failed: %v %v", lats, err)
+		}
+
+		flags, err := smysql.FindArray[bool](client, "flag", "SELECT flag FROM cities_test")
+		if err != nil || len(flags) == 0 {
+			t.Errorf("FindArray[bool] failed: %v %v", flags, err)
+		}
+
+		ids, err := smysql.FindArray[UserID](client, "id", "SELECT id FROM cities_test ORDER BY id")
+		if err != nil || len(ids) == 0 || ids[0] == 0 {
+			t.Errorf("FindArray[UserID] failed: %v %v", ids, err)
+		}
+
+		now, found, err := smysql.FirstColAny[time.Time](client, "SELECT NOW()")
+		if err != nil || !found || now.IsZero() {
+			t.Errorf("FirstColAny[time.Time] failed: %v %v", now, err)
+		}
+	})
+
+	t.Run("Structs", func(t *testing.T) {
+		cities, err := smysql.FindStructs[CityTest](client, "SELECT * FROM cities_test WHERE country_code = ?", "JP")
+		if err != nil || len(cities) != 2 {
+			t.Errorf("FindStructs failed: %d %v", len(cities), err)
+		}
+
+		city, found, err := smysql.FirstStruct[CityTest](client, "SELECT * FROM cities_test WHERE name = ?", "Tokyo")
+		if err != nil || !found || city.Name != "Tokyo" {
+			t.Errorf("FirstStruct failed: %+v %v", city, err)
+		}
+
+		if _, err := smysql.FindStructs[int64](client, "SELECT id FROM cities_test"); err == nil {
+			t.Error("Expected error for non-struct type, but got none")
+		}
+	})
+}
@@ -0,0 +1,416 @@
+package smysql
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+)
+
+// KV 有序结果中的一个键值对
+type KV[K any, V any] struct {
+	Key   K
+	Value V
+}
+
+// keyPart 组成键的一列
+type keyPart struct {
+	column    int          // 列索引
+	name      string       // 列名
+	index     []int        // 在键类型中的字段索引路径，键不是结构体时为 nil
+	typ       reflect.Type // 键或键字段的类型
+	valueInfo *fieldInfo   // 值结构体中映射到该列的字段，为 nil 时按键类型单独扫描
+}
+
+// scanKeyedRows 按键列逐行扫描结果集，每行的键和值交给 fn 处理，任一键列为 null 的行会被过滤掉
+// keyFields 有多列时为复合键，T 必须为结构体，按 db 标签映射各列
+// valueField 为空时值为整个结构体；值不是结构体时使用键以外唯一的列
+func scanKeyedRows[T comparable, Y any](client *MySQLClient, rows *sql.Rows, keyFields []string, valueField string, fn func(key T, value Y) error) error {
+	if len(keyFields) == 0 {
+		return fmt.Errorf("keyField cannot be empty")
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return fmt.Errorf("failed to get columns: %v", err)
+	}
+	columnIndex := func(name string) int {
+		for i, col := range columns {
+			if col == name {
+				return i
+			}
+		}
+		return -1
+	}
+
+	keyType := reflect.TypeOf((*T)(nil)).Elem()
+	yType := reflect.TypeOf((*Y)(nil)).Elem()
+	isStructType := yType.Kind() == reflect.Struct
+
+	// 查找键字段索引
+	var keyMapping *fieldsMapping
+	if len(keyFields) > 1 {
+		if keyType.Kind() != reflect.Struct {
+			return fmt.Errorf("key type must be a struct for composite keys, got %s", keyType)
+		}
+		keyMapping = client.getFieldsMapping(keyType)
+	}
+	parts := make([]keyPart, len(keyFields))
+	isKeyColumn := make([]bool, len(columns))
+	for i, name := range keyFields {
+		if name == "" {
+			return fmt.Errorf("keyField cannot be empty")
+		}
+		idx := columnIndex(name)
+		if idx == -1 {
+			return fmt.Errorf("key field '%s' not found in query results", name)
+		}
+		part := keyPart{column: idx, name: name, typ: keyType}
+		if keyMapping != nil {
+			info, ok := keyMapping.lookup(name)
+			if !ok {
+				return fmt.Errorf("key field '%s' is not mapped in %s", name, keyType)
+			}
+			part.index, part.typ = info.index, info.typ
+		}
+		parts[i] = part
+		isKeyColumn[idx] = true
+	}
+
+	// 查找值字段索引
+	valueIndex := -1
+	if valueField != "" {
+		if valueIndex = columnIndex(valueField); valueIndex == -1 {
+			return fmt.Errorf("value field '%s' not found in query results", valueField)
+		}
+	} else if !isStructType {
+		// 值不是结构体且未指定值字段时，使用键以外唯一的列
+		for i := range columns {
+			if isKeyColumn[i] {
+				continue
+			}
+			if valueIndex != -1 {
+				return fmt.Errorf("valueField cannot be empty when value type is not a struct and the result has more than one value column")
+			}
+			valueIndex = i
+		}
+		if valueIndex == -1 {
+			return fmt.Errorf("no value column found in query results")
+		}
+		valueField = columns[valueIndex]
+	}
+	structMode := isStructType && valueField == ""
+
+	// 结构体模式，获取字段映射，键列映射到结构体字段时从字段中读取键
+	var fieldsMapping *fieldsMapping
+	if structMode {
+		fieldsMapping = client.getFieldsMapping(yType)
+		// 键列不要求结构体中有对应字段
+		if err := client.checkMapping(yType, columns, fieldsMapping, keyFields...); err != nil {
+			return err
+		}
+		for i := range parts {
+			parts[i].valueInfo, _ = fieldsMapping.lookup(parts[i].name)
+		}
+	}
+
+	scanDest := make([]any, len(columns))
+	for rows.Next() {
+		// 为每列创建扫描目标
+		if structMode {
+			scanDest = client.newScanDest(columns, fieldsMapping)
+		} else {
+			for i := range columns {
+				scanDest[i] = &sql.NullString{}
+			}
+			scanDest[valueIndex] = client.createNullScanner(yType)
+		}
+		for _, part := range parts {
+			if part.valueInfo == nil {
+				scanDest[part.column] = client.createNullScanner(part.typ)
+			}
+		}
+
+		if err := rows.Scan(scanDest...); err != nil {
+			return fmt.Errorf("failed to scan row: %v", err)
+		}
+
+		// 如果键为null，跳过该行
+		hasNullKey := false
+		for _, part := range parts {
+			if isNullScan(scanDest[part.column]) {
+				hasNullKey = true
+				break
+			}
+		}
+		if hasNullKey {
+			continue
+		}
+
+		// 构建值
+		var value Y
+		var item reflect.Value
+		if structMode {
+			item = reflect.New(yType).Elem()
+			if err := client.setStructFields(item, columns, scanDest, fieldsMapping); err != nil {
+				return err
+			}
+			value = item.Interface().(Y)
+		} else {
+			// 值为null时使用Y类型的零值
+			v, _, err := scanColumnValue[Y](client, scanDest[valueIndex], valueField)
+			if err != nil {
+				return err
+			}
+			value = v
+		}
+
+		// 构建键
+		var key T
+		keyValue := reflect.ValueOf(&key).Elem()
+		for _, part := range parts {
+			target := keyValue
+			if part.index != nil {
+				target = fieldByIndexAlloc(keyValue, part.index)
+			}
+			if part.valueInfo != nil {
+				k, err := convertKey(item.FieldByIndex(part.valueInfo.index), part.typ)
+				if err != nil {
+					return fmt.Errorf("key field '%s': %v", part.name, err)
+				}
+				if k != nil {
+					target.Set(reflect.ValueOf(k))
+				}
+			} else if err := client.setFieldFromNullScanner(target, scanDest[part.column], part.typ); err != nil {
+				return fmt.Errorf("failed to set column %s: %v", part.name, err)
+			}
+		}
+
+		if err := fn(key, value); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("rows iteration error: %v", err)
+	}
+	return nil
+}
+
+// convertKey 将结构体中的键字段转换为 map 的键类型，数值可以转为字符串，返回 nil 表示 NULL
+// 指针字段取指向的值，注册类型和 driver.Valuer（如 sql.NullInt64）取数据库值，数值转换有精度损失或溢出时返回错误
+func convertKey(field reflect.Value, keyType reflect.Type) (any, error) {
+	if field.Type() == keyType {
+		return field.Interface(), nil
+	}
+
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return nil, nil
+		}
+		return convertKey(field.Elem(), keyType)
+	}
+	codec := lookupCodec(field.Type())
+	if (codec != nil && codec.encode != nil) || field.Type().Implements(valuerType) || reflect.PointerTo(field.Type()).Implements(valuerType) {
+		value, err := encodeArg(field.Interface())
+		if err != nil {
+			return nil, err
+		}
+		if valuer, ok := value.(driver.Valuer); ok {
+			if value, err = valuer.Value(); err != nil {
+				return nil, err
+			}
+		}
+		if value == nil {
+			return nil, nil
+		}
+		return convertKey(reflect.ValueOf(value), keyType)
+	}
+
+	isNumber := func(k reflect.Kind) bool {
+		return (k >= reflect.Int && k <= reflect.Uint64) || k == reflect.Float32 || k == reflect.Float64
+	}
+	switch {
+	case isNumber(field.Kind()) && isNumber(keyType.Kind()):
+		converted := field.Convert(keyType)
+		if !converted.Convert(field.Type()).Equal(field) || isNegative(converted) != isNegative(field) {
+			return nil, fmt.Errorf("cannot convert %v to %s without loss", field.Interface(), keyType)
+		}
+		return converted.Interface(), nil
+	case field.Kind() == reflect.String && keyType.Kind() == reflect.String:
+		return field.Convert(keyType).Interface(), nil
+	case isNumber(field.Kind()) && keyType.Kind() == reflect.String:
+		return reflect.ValueOf(fmt.Sprint(field.Interface())).Convert(keyType).Interface(), nil
+	case field.Type() == reflect.TypeOf([]byte(nil)) && keyType.Kind() == reflect.String:
+		return reflect.ValueOf(string(field.Bytes())).Convert(keyType).Interface(), nil
+	}
+	return nil, fmt.Errorf("cannot convert %s to %s", field.Type(), keyType)
+}
+
+// isNegative 判断数值是否小于 0
+func isNegative(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() < 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() < 0
+	}
+	return false
+}
+
+// queryRows 执行查询并将结果集交给 fn 处理
+func (client *MySQLClient) queryRows(query string, args []any, fn func(rows *sql.Rows) error) error {
+	query, args, err := expandArgs(query, args)
+	if err != nil {
+		return err
+	}
+	client.debugLog(query, args...)
+
+	stmt, err := client.DB.Prepare(query)
+	if err != nil {
+		return fmt.Errorf("failed to prepare query: %v", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(args...)
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %v", err)
+	}
+	defer rows.Close()
+
+	return fn(rows)
+}
+
+// queryProcRows 执行存储过程并将结果集交给 fn 处理
+func (client *MySQLClient) queryProcRows(procName string, args []any, fn func(rows *sql.Rows) error) error {
+	client.debugLog(procName, args...)
+
+	query, args, err := client.procQuery(procName, args)
+	if err != nil {
+		return err
+	}
+
+	stmt, err := client.DB.Prepare(query)
+	if err != nil {
+		return fmt.Errorf("failed to prepare query: %v", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(args...)
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %v", err)
+	}
+	defer rows.Close()
+
+	return fn(rows)
+}
+
+// groupRows 按键列分组收集结果集，同一键的值按查询顺序追加
+func groupRows[K comparable, V any](client *MySQLClient, rows *sql.Rows, keyField string) (map[K][]V, error) {
+	result := make(map[K][]V)
+	err := scanKeyedRows[K, V](client, rows, []string{keyField}, "", func(key K, value V) error {
+		result[key] = append(result[key], value)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result) == 0 {
+		return nil, nil
+	}
+	return result, nil
+}
+
+// mapRows 按复合键收集结果集为 map，键重复时后面的行覆盖前面的行
+func mapRows[K comparable, V any](client *MySQLClient, rows *sql.Rows, keyFields []string, valueField string) (map[K]V, error) {
+	result := make(map[K]V)
+	err := scanKeyedRows[K, V](client, rows, keyFields, valueField, func(key K, value V) error {
+		result[key] = value
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result) == 0 {
+		return nil, nil
+	}
+	return result, nil
+}
+
+// orderedRows 按查询顺序收集结果集为键值对切片，重复的键会保留每一行
+func orderedRows[K comparable, V any](client *MySQLClient, rows *sql.Rows, keyField string, valueField string) ([]KV[K, V], error) {
+	var result []KV[K, V]
+	err := scanKeyedRows[K, V](client, rows, []string{keyField}, valueField, func(key K, value V) error {
+		result = append(result, KV[K, V]{Key: key, Value: value})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// FindGroup 执行查询并按 keyField 分组返回 map[K][]V，适用于一对多查询，如按国家查询城市
+// V 为结构体时映射整行，否则使用键以外唯一的列；key 为 null 的行会被过滤掉
+func FindGroup[K comparable, V any](client *MySQLClient, keyField string, query string, args ...any) (map[K][]V, error) {
+	var result map[K][]V
+	err := client.queryRows(query, args, func(rows *sql.Rows) (err error) {
+		result, err = groupRows[K, V](client, rows, keyField)
+		return err
+	})
+	return result, err
+}
+
+// FindProcGroup 执行存储过程并按 keyField 分组返回 map[K][]V
+func FindProcGroup[K comparable, V any](client *MySQLClient, keyField string, procName string, args ...any) (map[K][]V, error) {
+	var result map[K][]V
+	err := client.queryProcRows(procName, args, func(rows *sql.Rows) (err error) {
+		result, err = groupRows[K, V](client, rows, keyField)
+		return err
+	})
+	return result, err
+}
+
+// FindMapKeys 执行查询并返回以多列为复合键的 map[K]V，K 为可比较的结构体，按 db 标签映射 keyFields 中的列
+// 例如 FindMapKeys[CityKey, City](client, []string{"country_id", "state_id"}, "", query)
+func FindMapKeys[K comparable, V any](client *MySQLClient, keyFields []string, valueField string, query string, args ...any) (map[K]V, error) {
+	var result map[K]V
+	err := client.queryRows(query, args, func(rows *sql.Rows) (err error) {
+		result, err = mapRows[K, V](client, rows, keyFields, valueField)
+		return err
+	})
+	return result, err
+}
+
+// FindProcMapKeys 执行存储过程并返回以多列为复合键的 map[K]V
+func FindProcMapKeys[K comparable, V any](client *MySQLClient, keyFields []string, valueField string, procName string, args ...any) (map[K]V, error) {
+	var result map[K]V
+	err := client.queryProcRows(procName, args, func(rows *sql.Rows) (err error) {
+		result, err = mapRows[K, V](client, rows, keyFields, valueField)
+		return err
+	})
+	return result, err
+}
+
+// FindOrdered 执行查询并按 SQL 的 ORDER BY 顺序返回键值对切片，适用于排行榜等依赖顺序的查询
+// 参数同 FindMap，重复的键会保留每一行
+func FindOrdered[K comparable, V any](client *MySQLClient, keyField string, valueField string, query string, args ...any) ([]KV[K, V], error) {
+	var result []KV[K, V]
+	err := client.queryRows(query, args, func(rows *sql.Rows) (err error) {
+		result, err = orderedRows[K, V](client, rows, keyField, valueField)
+		return err
+	})
+	return result, err
+}
+
+// FindProcOrdered 执行存储过程并按结果顺序返回键值对切片
+func FindProcOrdered[K comparable, V any](client *MySQLClient, keyField string, valueField string, procName string, args ...any) ([]KV[K, V], error) {
+	var result []KV[K, V]
+	err := client.queryProcRows(procName, args, func(rows *sql.Rows) (err error) {
+		result, err = orderedRows[K, V](client, rows, keyField, valueField)
+		return err
+	})
+	return result, err
+}
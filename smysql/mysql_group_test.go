@@ -0,0 +1,165 @@
+package smysql_test
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/Xuzan9396/zmysql/smysql"
+)
+
+// TestFindGroup 测试按键分组查询以及 FindMapUnique 的重复键检查
+func TestFindGroup(t *testing.T) {
+	client, err := getTestClient()
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if err := setupTestData(client); err != nil {
+		t.Fatalf("failed to setup test data: %v", err)
+	}
+
+	t.Run("StructValues", func(t *testing.T) {
+		groups, err := smysql.FindGroup[string, CityTest](client, "country_code", "SELECT * FROM cities_test ORDER BY id")
+		if err != nil {
+			t.Fatalf("FindGroup failed: %v", err)
+		}
+		if len(groups["CN"]) != 4 || len(groups["JP"]) != 2 {
+			t.Errorf("Expected 4 CN and 2 JP cities, got %d and %d", len(groups["CN"]), len(groups["JP"]))
+		}
+		if groups["JP"][0].Name != "Tokyo" && groups["JP"][0].Name != "Osaka" {
+			t.Errorf("Unexpected JP city: %+v", groups["JP"][0])
+		}
+	})
+
+	t.Run("ScalarValues", func(t *testing.T) {
+		groups, err := smysql.FindGroup[string, string](client, "country_code", "SELECT country_code, name FROM cities_test ORDER BY name")
+		if err != nil {
+			t.Fatalf("FindGroup failed: %v", err)
+		}
+		if strings.Join(groups["JP"], ",") != "Osaka,Tokyo" {
+			t.Errorf("Expected [Osaka Tokyo], got %v", groups["JP"])
+		}
+	})
+
+	t.Run("AmbiguousScalarValue", func(t *testing.T) {
+		if _, err := smysql.FindGroup[string, string](client, "country_code", "SELECT country_code, name, state_code FROM cities_test"); err == nil {
+			t.Error("Expected error for ambiguous value column, but got none")
+		}
+	})
+
+	t.Run("PointerAndScannerKeys", func(t *testing.T) {
+		type KeyCity struct {
+			ID        *int64        `db:"id"`
+			CountryID sql.NullInt64 `db:"country_id"`
+			Name      string        `db:"name"`
+		}
+		byID, err := smysql.FindGroup[int64, KeyCity](client, "id", "SELECT id, country_id, name FROM cities_test")
+		if err != nil {
+			t.Fatalf("FindGroup with pointer key failed: %v", err)
+		}
+		if len(byID) != 10 {
+			t.Errorf("Expected 10 groups, got %d", len(byID))
+		}
+		byCountry, err := smysql.FindGroup[int64, KeyCity](client, "country_id", "SELECT id, country_id, name FROM cities_test")
+		if err != nil {
+			t.Fatalf("FindGroup with scanner key failed: %v", err)
+		}
+		if len(byCountry[1]) != 4 || len(byCountry[2]) != 2 {
+			t.Errorf("Expected 4 and 2 cities for countries 1 and 2, got %d and %d", len(byCountry[1]), len(byCountry[2]))
+		}
+	})
+
+	t.Run("LossyKeyConversion", func(t *testing.T) {
+		type LatCity struct {
+			Latitude float64 `db:"latitude"`
+			Name     string  `db:"name"`
+		}
+		if _, err := smysql.FindGroup[int64, LatCity](client, "latitude", "SELECT latitude, name FROM cities_test"); err == nil {
+			t.Error("Expected error for lossy key conversion, but got none")
+		}
+	})
+
+	t.Run("FindMapUnique", func(t *testing.T) {
+		_, err := smysql.FindMapUnique[string, string](client, "country_code", "name", "SELECT country_code, name FROM cities_test")
+		if err == nil || !strings.Contains(err.Error(), "duplicate key") {
+			t.Errorf("Expected duplicate key error, got %v", err)
+		}
+
+		m, err := smysql.FindMapUnique[string, int64](client, "name", "id", "SELECT name, id FROM cities_test")
+		if err != nil || len(m) == 0 {
+			t.Errorf("FindMapUnique with unique keys failed: %v %v", m, err)
+		}
+
+		// 与 FindMap 相同，可以直接传入构建器
+		_, err = smysql.FindMapUnique[string, string](client, "country_code", "name",
+			smysql.Select("country_code", "name").From("cities_test"))
+		if err == nil || !strings.Contains(err.Error(), "duplicate key") {
+			t.Errorf("Expected duplicate key error with builder, got %v", err)
+		}
+		m, err = smysql.FindMapUnique[string, int64](client, "name", "id",
+			smysql.Select("name", "id").From("cities_test").Where("country_code = ?", "JP"))
+		if err != nil || len(m) != 2 {
+			t.Errorf("FindMapUnique with builder failed: %v %v", m, err)
+		}
+	})
+}
+
+// TestCompositeAndOrdered 测试复合键映射和保持顺序的键值对结果
+func TestCompositeAndOrdered(t *testing.T) {
+	client, err := getTestClient()
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if err := setupTestData(client); err != nil {
+		t.Fatalf("failed to setup test data: %v", err)
+	}
+
+	type StateKey struct {
+		CountryID int64 `db:"country_id"`
+		StateID   int64 `db:"state_id"`
+	}
+
+	t.Run("CompositeKey", func(t *testing.T) {
+		cities, err := smysql.FindMapKeys[StateKey, CityTest](client, []string{"country_id", "state_id"}, "",
+			"SELECT * FROM cities_test ORDER BY id")
+		if err != nil {
+			t.Fatalf("FindMapKeys failed: %v", err)
+		}
+		for key, city := range cities {
+			if int64(city.CountryID) != key.CountryID || int64(city.StateID) != key.StateID {
+				t.Errorf("Key %+v does not match city %+v", key, city)
+			}
+		}
+
+		names, err := smysql.FindMapKeys[StateKey, string](client, []string{"country_id", "state_id"}, "name",
+			"SELECT country_id, state_id, name FROM cities_test")
+		if err != nil || len(names) == 0 {
+			t.Errorf("FindMapKeys with value field failed: %v %v", names, err)
+		}
+	})
+
+	t.Run("CompositeKeyRequiresStruct", func(t *testing.T) {
+		_, err := smysql.FindMapKeys[int64, string](client, []string{"country_id", "state_id"}, "name",
+			"SELECT country_id, state_id, name FROM cities_test")
+		if err == nil {
+			t.Error("Expected error for non-struct composite key, but got none")
+		}
+	})
+
+	t.Run("Ordered", func(t *testing.T) {
+		ranking, err := smysql.FindOrdered[string, float64](client, "name", "latitude",
+			"SELECT name, latitude FROM cities_test ORDER BY latitude DESC")
+		if err != nil {
+			t.Fatalf("FindOrdered failed: %v", err)
+		}
+		for i := 1; i < len(ranking); i++ {
+			if ranking[i-1].Value < ranking[i].Value {
+				t.Errorf("Expected descending order, got %v before %v", ranking[i-1], ranking[i])
+			}
+		}
+	})
+}
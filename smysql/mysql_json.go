@@ -0,0 +1,295 @@
+package smysql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type jsonKind int8
+
+const (
+	jsonRaw     jsonKind = iota // 保持驱动返回的值，BLOB 等二进制列输出为 base64
+	jsonString                  // 字符串
+	jsonNumber                  // 整数、浮点数
+	jsonDecimal                 // DECIMAL，按 decimalMode 输出
+	jsonBool                    // 整数列输出为布尔值
+	jsonBitBool                 // BIT 列输出为布尔值
+	jsonBit                     // BIT 列输出为整数
+	jsonTime                    // DATETIME、TIMESTAMP 输出为 RFC3339 字符串
+	jsonDate                    // DATE 输出为 2006-01-02
+	jsonRawJSON                 // JSON 列原样嵌入
+)
+
+// WithJSONKeyMapper 设置 ExecByte 等 JSON 输出中列名到键名的转换，如 CamelCase
+func WithJSONKeyMapper(mapper func(column string) string) func(*MySQLClient) {
+	return func(client *MySQLClient) {
+		client.jsonKeyMapper = mapper
+	}
+}
+
+// WithJSONOmitNull ExecByte 等 JSON 输出中省略值为 NULL 的键
+func WithJSONOmitNull() func(*MySQLClient) {
+	return func(client *MySQLClient) {
+		client.jsonOmitNull = true
+	}
+}
+
+// WithJSONBool 指定 ExecByte 等 JSON 输出中作为布尔值输出的列，不指定列时所有 TINYINT、BIT 列都输出为布尔值
+// 驱动不提供 TINYINT(1) 的显示宽度，无法自动识别标志列
+func WithJSONBool(columns ...string) func(*MySQLClient) {
+	return func(client *MySQLClient) {
+		if len(columns) == 0 {
+			client.jsonBoolAll = true
+			return
+		}
+		if client.jsonBoolColumns == nil {
+			client.jsonBoolColumns = make(map[string]bool, len(columns))
+		}
+		for _, col := range columns {
+			client.jsonBoolColumns[strings.ToLower(col)] = true
+		}
+	}
+}
+
+// columnKind 根据列类型决定输出方式
+func columnKind(columnType *sql.ColumnType) jsonKind {
+	switch strings.TrimPrefix(strings.ToUpper(columnType.DatabaseTypeName()), "UNSIGNED ") {
+	case "TINYINT", "SMALLINT", "MEDIUMINT", "INT", "BIGINT", "YEAR", "FLOAT", "DOUBLE":
+		return jsonNumber
+	case "BIT":
+		return jsonBit
+	case "DECIMAL":
+		return jsonDecimal
+	case "DATETIME", "TIMESTAMP":
+		return jsonTime
+	case "DATE":
+		return jsonDate
+	case "JSON":
+		return jsonRawJSON
+	case "CHAR", "VARCHAR", "TEXT", "TINYTEXT", "MEDIUMTEXT", "LONGTEXT", "ENUM", "SET", "TIME":
+		return jsonString
+	}
+	return jsonRaw
+}
+
+// jsonColumnKind 根据列类型和 WithJSONBool 决定 JSON 输出方式，只有整数和 BIT 列可以输出为布尔值
+func (client *MySQLClient) jsonColumnKind(column string, columnType *sql.ColumnType) jsonKind {
+	kind := columnKind(columnType)
+	typeName := strings.TrimPrefix(strings.ToUpper(columnType.DatabaseTypeName()), "UNSIGNED ")
+	if kind == jsonNumber && (typeName == "FLOAT" || typeName == "DOUBLE") {
+		return kind
+	}
+	if kind != jsonNumber && kind != jsonBit {
+		return kind
+	}
+
+	isBool := client.jsonBoolColumns[strings.ToLower(column)] || (client.jsonBoolAll && (typeName == "TINYINT" || typeName == "BIT"))
+	switch {
+	case isBool && kind == jsonBit:
+		return jsonBitBool
+	case isBool:
+		return jsonBool
+	}
+	return kind
+}
+
+// jsonColumnValue 将驱动返回的值转换为 JSON 值，NULL 和零值日期返回 nil
+// 预处理语句（二进制协议）和普通查询（文本协议）返回的值类型不同，两者都需要处理
+func (client *MySQLClient) jsonColumnValue(value any, kind jsonKind) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	switch kind {
+	case jsonString:
+		if b, ok := value.([]byte); ok {
+			return string(b), nil
+		}
+	case jsonNumber:
+		switch v := value.(type) {
+		case []byte:
+			return json.Number(v), nil
+		case string:
+			return json.Number(v), nil
+		}
+	case jsonDecimal:
+		return decimalJSONValue(value, client.decimalMode), nil
+	case jsonBool:
+		switch v := value.(type) {
+		case int64:
+			return v != 0, nil
+		case uint64:
+			return v != 0, nil
+		case []byte:
+			n, err := strconv.ParseInt(string(v), 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			return n != 0, nil
+		}
+	case jsonBit, jsonBitBool:
+		b, ok := value.([]byte)
+		if !ok {
+			return value, nil
+		}
+		var n uint64
+		for _, c := range b {
+			n = n<<8 | uint64(c)
+		}
+		if kind == jsonBitBool {
+			return n != 0, nil
+		}
+		return n, nil
+	case jsonTime, jsonDate:
+		s := timeScanner{loc: client.location}
+		if err := s.Scan(value); err != nil {
+			return nil, err
+		}
+		if !s.valid {
+			return nil, nil
+		}
+		if kind == jsonDate {
+			return s.t.Format(time.DateOnly), nil
+		}
+		return s.t.Format(time.RFC3339Nano), nil
+	case jsonRawJSON:
+		if b, ok := value.([]byte); ok {
+			if json.Valid(b) {
+				return json.RawMessage(b), nil
+			}
+			return string(b), nil
+		}
+	}
+	return value, nil
+}
+
+// rowEncoder 将结果集的行转换为 JSON 对象，列信息只在创建时读取一次
+type rowEncoder struct {
+	client   *MySQLClient
+	columns  []string
+	keys     []string
+	kinds    []jsonKind
+	values   []any
+	pointers []any
+}
+
+// newRowEncoder 根据当前结果集的列信息创建 rowEncoder
+func (client *MySQLClient) newRowEncoder(rows *sql.Rows) (*rowEncoder, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get columns: %v", err)
+	}
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get column types: %v", err)
+	}
+
+	e := &rowEncoder{
+		client:   client,
+		columns:  columns,
+		keys:     make([]string, len(columns)),
+		kinds:    make([]jsonKind, len(columns)),
+		values:   make([]any, len(columns)),
+		pointers: make([]any, len(columns)),
+	}
+	for i, col := range columns {
+		e.keys[i] = col
+		if client.jsonKeyMapper != nil {
+			e.keys[i] = client.jsonKeyMapper(col)
+		}
+		e.kinds[i] = client.jsonColumnKind(col, columnTypes[i])
+		e.pointers[i] = &e.values[i]
+	}
+	return e, nil
+}
+
+// scan 扫描当前行并转换为 map，NULL 值按 jsonOmitNull 决定是否保留
+func (e *rowEncoder) scan(rows *sql.Rows) (map[string]any, error) {
+	if err := rows.Scan(e.pointers...); err != nil {
+		return nil, fmt.Errorf("failed to scan row: %v", err)
+	}
+
+	rowMap := make(map[string]any, len(e.columns))
+	for i, key := range e.keys {
+		value, err := e.client.jsonColumnValue(e.values[i], e.kinds[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert column %s: %v", e.columns[i], err)
+		}
+		if value == nil && e.client.jsonOmitNull {
+			continue
+		}
+		rowMap[key] = value
+	}
+	return rowMap, nil
+}
+
+// ExecByteMulti 执行查询并返回所有结果集的 JSON，多条语句需要开启 WithMultiStatements 且不能使用 ? 参数
+// names 为空时返回结果集数组 [[...],[...]]，否则返回以 names 为键的对象 {"name1":[...],"name2":[...]}
+func (client *MySQLClient) ExecByteMulti(query string, names []string, args ...any) ([]byte, error) {
+	sets, err := client.QueryResultSets(context.Background(), query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer sets.Close()
+
+	return client.marshalResultSets(sets, names)
+}
+
+// ExecProcByteMulti 执行存储过程并返回所有结果集的 JSON，格式同 ExecByteMulti
+func (client *MySQLClient) ExecProcByteMulti(procName string, names []string, args ...any) ([]byte, error) {
+	sets, err := client.ProcResultSets(context.Background(), procName, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer sets.Close()
+
+	return client.marshalResultSets(sets, names)
+}
+
+// marshalResultSets 读取所有结果集并序列化为 JSON，每个结果集都输出为数组，空结果集为 []
+// 指定 names 时结果集数量超过 names 返回错误，缺少的结果集输出为 []
+func (client *MySQLClient) marshalResultSets(sets *ResultSets, names []string) ([]byte, error) {
+	var resultSets []any
+	for sets.NextSet() {
+		if len(names) > 0 && sets.Index() >= len(names) {
+			return nil, fmt.Errorf("too many result sets, expected %d", len(names))
+		}
+		resultData, err := client.scanRowMaps(sets.rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan result set %d: %v", sets.Index(), err)
+		}
+		if resultData == nil {
+			resultData = []map[string]any{}
+		}
+		resultSets = append(resultSets, resultData)
+	}
+	if err := sets.Err(); err != nil {
+		return nil, err
+	}
+
+	var result any = resultSets
+	if len(names) > 0 {
+		named := make(map[string]any, len(names))
+		for i, name := range names {
+			if i < len(resultSets) {
+				named[name] = resultSets[i]
+			} else {
+				named[name] = []map[string]any{}
+			}
+		}
+		result = named
+	} else if resultSets == nil {
+		result = []any{}
+	}
+
+	jsonData, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal result data: %v", err)
+	}
+	return jsonData, nil
+}
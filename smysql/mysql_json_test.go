@@ -0,0 +1,265 @@
+package smysql_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Xuzan9396/zmysql/smysql"
+)
+
+// TestExecByteMulti 测试多结果集 JSON 输出
+func TestExecByteMulti(t *testing.T) {
+	client, err := getTestClient(smysql.WithMultiStatements())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if err := setupTestData(client); err != nil {
+		t.Fatalf("failed to setup test data: %v", err)
+	}
+
+	query := "SELECT id, name FROM cities_test WHERE country_code = 'JP'; SELECT COUNT(*) AS total FROM cities_test WHERE country_code = 'XX'"
+
+	t.Run("Array", func(t *testing.T) {
+		data, err := client.ExecByteMulti(query, nil)
+		if err != nil {
+			t.Fatalf("ExecByteMulti failed: %v", err)
+		}
+
+		var result [][]map[string]any
+		if err := json.Unmarshal(data, &result); err != nil {
+			t.Fatalf("Failed to unmarshal result: %v", err)
+		}
+		if len(result) != 2 {
+			t.Fatalf("Expected 2 result sets, got %d", len(result))
+		}
+		if len(result[0]) != 2 || len(result[1]) != 1 {
+			t.Errorf("Unexpected result set sizes: %s", data)
+		}
+	})
+
+	t.Run("Named", func(t *testing.T) {
+		data, err := client.ExecByteMulti(query, []string{"cities", "stats", "extra"})
+		if err != nil {
+			t.Fatalf("ExecByteMulti failed: %v", err)
+		}
+
+		var result map[string][]map[string]any
+		if err := json.Unmarshal(data, &result); err != nil {
+			t.Fatalf("Failed to unmarshal result: %v", err)
+		}
+		if len(result["cities"]) != 2 || len(result["stats"]) != 1 {
+			t.Errorf("Unexpected result: %s", data)
+		}
+		if extra, ok := result["extra"]; !ok || len(extra) != 0 {
+			t.Errorf("Expected empty extra result set, got %s", data)
+		}
+	})
+
+	t.Run("TooManyResultSets", func(t *testing.T) {
+		if _, err := client.ExecByteMulti(query, []string{"cities"}); err == nil {
+			t.Error("Expected error for too many result sets, but got none")
+		}
+	})
+
+	t.Run("Procedure", func(t *testing.T) {
+		if _, err := client.DB.Exec("DROP PROCEDURE IF EXISTS cities_json_test"); err != nil {
+			t.Fatalf("failed to drop procedure: %v", err)
+		}
+		_, err := client.DB.Exec(`CREATE PROCEDURE cities_json_test(IN p_country CHAR(2))
+BEGIN
+	SELECT id, name FROM cities_test WHERE country_code = p_country;
+	SELECT COUNT(*) AS total FROM cities_test WHERE country_code = p_country;
+END`)
+		if err != nil {
+			t.Fatalf("failed to create procedure: %v", err)
+		}
+		defer client.DB.Exec("DROP PROCEDURE IF EXISTS cities_json_test")
+
+		data, err := client.ExecProcByteMulti("cities_json_test", []string{"cities", "stats"}, "US")
+		if err != nil {
+			t.Fatalf("ExecProcByteMulti failed: %v", err)
+		}
+
+		var result map[string][]map[string]any
+		if err := json.Unmarshal(data, &result); err != nil {
+			t.Fatalf("Failed to unmarshal result: %v", err)
+		}
+		if len(result["cities"]) != 2 || len(result["stats"]) != 1 {
+			t.Errorf("Unexpected result: %s", data)
+		}
+	})
+}
+
+// TestExecByteTyped 测试 ExecByte 按列类型输出 JSON
+func TestExecByteTyped(t *testing.T) {
+	client, err := getTestClient()
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if err := setupTestData(client); err != nil {
+		t.Fatalf("failed to setup test data: %v", err)
+	}
+
+	query := "SELECT id, name, latitude, flag, created_at, wikiDataId, JSON_OBJECT('a', 1) AS extra FROM cities_test WHERE name = ?"
+
+	t.Run("Types", func(t *testing.T) {
+		data, err := client.ExecByte(query, smysql.HAS_ONE, "Beijing")
+		if err != nil {
+			t.Fatalf("ExecByte failed: %v", err)
+		}
+
+		var result map[string]any
+		if err := json.Unmarshal(data, &result); err != nil {
+			t.Fatalf("Failed to unmarshal result: %v", err)
+		}
+		if result["name"] != "Beijing" {
+			t.Errorf("Expected name Beijing, got %v", result["name"])
+		}
+		if _, ok := result["id"].(float64); !ok {
+			t.Errorf("Expected numeric id, got %T", result["id"])
+		}
+		if result["latitude"] != 39.9042 {
+			t.Errorf("Expected numeric latitude 39.9042, got %v", result["latitude"])
+		}
+		if result["flag"] != float64(1) {
+			t.Errorf("Expected flag 1, got %v", result["flag"])
+		}
+		createdAt, ok := result["created_at"].(string)
+		if !ok {
+			t.Fatalf("Expected string created_at, got %T", result["created_at"])
+		}
+		if _, err := time.Parse(time.RFC3339, createdAt); err != nil {
+			t.Errorf("Expected RFC3339 created_at, got %s", createdAt)
+		}
+		if extra, ok := result["extra"].(map[string]any); !ok || extra["a"] != float64(1) {
+			t.Errorf("Expected embedded JSON object, got %v", result["extra"])
+		}
+		t.Logf("ExecByte typed result: %s", data)
+	})
+
+	t.Run("Options", func(t *testing.T) {
+		optClient, err := getTestClient(smysql.WithJSONKeyMapper(smysql.CamelCase), smysql.WithJSONOmitNull(), smysql.WithJSONBool("flag"))
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+		defer optClient.Close()
+
+		if _, err := optClient.Exec("UPDATE cities_test SET wikiDataId = NULL WHERE name = ?", "Osaka"); err != nil {
+			t.Fatalf("Exec failed: %v", err)
+		}
+
+		data, err := optClient.ExecByte(query, smysql.HAS_ONE, "Osaka")
+		if err != nil {
+			t.Fatalf("ExecByte failed: %v", err)
+		}
+
+		var result map[string]any
+		if err := json.Unmarshal(data, &result); err != nil {
+			t.Fatalf("Failed to unmarshal result: %v", err)
+		}
+		if result["flag"] != false {
+			t.Errorf("Expected flag false, got %v", result["flag"])
+		}
+		if _, ok := result["createdAt"]; !ok {
+			t.Errorf("Expected camelCase key createdAt, got %s", data)
+		}
+		if _, ok := result["wikiDataId"]; ok {
+			t.Errorf("Expected NULL wikiDataId to be omitted, got %s", data)
+		}
+	})
+}
+
+// TestStreamJSON 测试流式 JSON 和 NDJSON 输出
+func TestStreamJSON(t *testing.T) {
+	client, err := getTestClient()
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if err := setupTestData(client); err != nil {
+		t.Fatalf("failed to setup test data: %v", err)
+	}
+
+	query := "SELECT id, name, latitude FROM cities_test WHERE country_code IN (?) ORDER BY id"
+
+	t.Run("JSON", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := client.StreamJSON(context.Background(), &buf, query, []string{"CN", "JP"}); err != nil {
+			t.Fatalf("StreamJSON failed: %v", err)
+		}
+
+		expected, err := client.ExecByte("SELECT id, name, latitude FROM cities_test WHERE country_code IN ('CN', 'JP') ORDER BY id", smysql.HAS_LIST)
+		if err != nil {
+			t.Fatalf("ExecByte failed: %v", err)
+		}
+		if buf.String() != string(expected) {
+			t.Errorf("Expected StreamJSON to match ExecByte\n got: %s\nwant: %s", buf.String(), expected)
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := client.StreamJSON(context.Background(), &buf, query, []string{"XX"}); err != nil {
+			t.Fatalf("StreamJSON failed: %v", err)
+		}
+		if buf.String() != "[]" {
+			t.Errorf("Expected [], got %s", buf.String())
+		}
+	})
+
+	t.Run("NDJSON", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := client.StreamNDJSON(context.Background(), &buf, query, []string{"CN", "JP"}); err != nil {
+			t.Fatalf("StreamNDJSON failed: %v", err)
+		}
+
+		count := 0
+		scanner := bufio.NewScanner(&buf)
+		for scanner.Scan() {
+			var row map[string]any
+			if err := json.Unmarshal(scanner.Bytes(), &row); err != nil {
+				t.Fatalf("Failed to unmarshal line %q: %v", scanner.Text(), err)
+			}
+			count++
+		}
+		if count != 6 {
+			t.Errorf("Expected 6 lines, got %d", count)
+		}
+	})
+
+	t.Run("Cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		w := &cancelWriter{cancel: cancel, after: 2}
+		err := client.StreamNDJSON(ctx, w, query, []string{"CN", "JP"})
+		if err == nil {
+			t.Fatal("Expected error after cancel, but got none")
+		}
+		if w.lines != 2 {
+			t.Errorf("Expected 2 lines before cancel, got %d", w.lines)
+		}
+	})
+}
+
+// cancelWriter 写入 after 行后取消 context
+type cancelWriter struct {
+	cancel func()
+	after  int
+	lines  int
+}
+
+func (w *cancelWriter) Write(p []byte) (int, error) {
+	w.lines++
+	if w.lines == w.after {
+		w.cancel()
+	}
+	return len(p), nil
+}
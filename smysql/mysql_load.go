@@ -0,0 +1,130 @@
+package smysql
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync/atomic"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+type LOAD_DUPLICATE int8
+
+const (
+	LOAD_DEFAULT LOAD_DUPLICATE = iota // 默认，LOCAL 模式下服务端按 IGNORE 处理
+	LOAD_REPLACE                       // 替换唯一键重复的行
+	LOAD_IGNORE                        // 跳过唯一键重复的行
+)
+
+// LoadDataOptions LoadData 的选项，默认格式与 ExportCSV 的输出一致
+type LoadDataOptions struct {
+	Header      bool           // 跳过第一行列名
+	Delimiter   rune           // 字段分隔符，默认 ','，TSV 使用 '\t'
+	LineEnd     string         // 行结束符，默认 "\n"
+	OnDuplicate LOAD_DUPLICATE // 唯一键重复时的处理方式
+}
+
+// LoadResult LoadData 的结果
+type LoadResult struct {
+	Rows     int64 // 受影响行数，REPLACE 替换的行计为 2
+	Warnings int64 // 警告数，如数据被截断、唯一键重复被跳过
+}
+
+// loadReaderSeq 用于生成唯一的 Reader 名称
+var loadReaderSeq atomic.Int64
+
+// LoadData 通过 LOAD DATA LOCAL INFILE 将 reader 中的数据批量导入 table，columns 为空时按表的列顺序导入
+// 字段可以用双引号包裹，\N 表示 NULL，反斜杠为转义符；服务端需要开启 local_infile
+func (client *MySQLClient) LoadData(ctx context.Context, table string, columns []string, reader io.Reader, opts LoadDataOptions) (*LoadResult, error) {
+	if table == "" {
+		return nil, fmt.Errorf("table cannot be empty")
+	}
+	if reader == nil {
+		return nil, fmt.Errorf("reader cannot be nil")
+	}
+
+	var quotedColumns []string
+	if len(columns) > 0 {
+		tableColumns, err := client.tableColumns(ctx, table)
+		if err != nil {
+			return nil, err
+		}
+		for _, col := range columns {
+			name, ok := tableColumns[strings.ToLower(col)]
+			if !ok {
+				return nil, fmt.Errorf("unknown column '%s' for table '%s'", col, table)
+			}
+			quotedColumns = append(quotedColumns, quoteIdent(name))
+		}
+	}
+
+	delimiter := ","
+	if opts.Delimiter != 0 {
+		delimiter = string(opts.Delimiter)
+	}
+	lineEnd := "\n"
+	if opts.LineEnd != "" {
+		lineEnd = opts.LineEnd
+	}
+
+	// 驱动在请求结束时会关闭 io.ReadCloser，包装一层避免关闭调用方的 reader
+	name := fmt.Sprintf("zmysql_load_%d", loadReaderSeq.Add(1))
+	mysql.RegisterReaderHandler(name, func() io.Reader {
+		return struct{ io.Reader }{reader}
+	})
+	defer mysql.DeregisterReaderHandler(name)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "LOAD DATA LOCAL INFILE 'Reader::%s'", name)
+	switch opts.OnDuplicate {
+	case LOAD_REPLACE:
+		sb.WriteString(" REPLACE")
+	case LOAD_IGNORE:
+		sb.WriteString(" IGNORE")
+	}
+	fmt.Fprintf(&sb, " INTO TABLE %s CHARACTER SET utf8mb4", quoteIdent(table))
+	fmt.Fprintf(&sb, " FIELDS TERMINATED BY %s OPTIONALLY ENCLOSED BY '\"' ESCAPED BY '\\\\'", quoteString(delimiter))
+	fmt.Fprintf(&sb, " LINES TERMINATED BY %s", quoteString(lineEnd))
+	if opts.Header {
+		sb.WriteString(" IGNORE 1 LINES")
+	}
+	if len(quotedColumns) > 0 {
+		fmt.Fprintf(&sb, " (%s)", strings.Join(quotedColumns, ", "))
+	}
+	query := sb.String()
+	client.debugLog(query)
+
+	// 警告数只能在同一连接上查询
+	conn, err := client.DB.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connection: %v", err)
+	}
+	defer conn.Close()
+
+	result, err := conn.ExecContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load data: %v", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get affected rows: %v", err)
+	}
+
+	loadResult := &LoadResult{Rows: rows}
+	if err := conn.QueryRowContext(ctx, "SELECT @@warning_count").Scan(&loadResult.Warnings); err != nil {
+		return nil, fmt.Errorf("failed to get warning count: %v", err)
+	}
+	return loadResult, nil
+}
+
+// quoteString 将字符串转义为 SQL 字符串字面量，用于不支持占位符的语句
+func quoteString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `'`, `\'`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	s = strings.ReplaceAll(s, "\r", `\r`)
+	s = strings.ReplaceAll(s, "\t", `\t`)
+	return "'" + s + "'"
+}
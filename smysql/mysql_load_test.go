@@ -0,0 +1,103 @@
+package smysql_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Xuzan9396/zmysql/smysql"
+)
+
+// TestLoadData 测试 LOAD DATA 批量导入，需要服务端开启 local_infile
+func TestLoadData(t *testing.T) {
+	client, err := getTestClient()
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if err := setupTestData(client); err != nil {
+		t.Fatalf("failed to setup test data: %v", err)
+	}
+
+	if _, err := client.DB.Exec("DROP TABLE IF EXISTS cities_load_test"); err != nil {
+		t.Fatalf("failed to drop table: %v", err)
+	}
+	if _, err := client.DB.Exec("CREATE TABLE cities_load_test LIKE cities_test"); err != nil {
+		t.Fatalf("failed to create table: %v", err)
+	}
+	defer client.DB.Exec("DROP TABLE IF EXISTS cities_load_test")
+
+	ctx := context.Background()
+	columns := []string{"id", "name", "country_code", "latitude", "wikiDataId"}
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		if _, err := client.Exec("UPDATE cities_test SET name = ?, wikiDataId = NULL WHERE name = ?", `Osaka, "JP" \ 1`, "Osaka"); err != nil {
+			t.Fatalf("Exec failed: %v", err)
+		}
+
+		var buf bytes.Buffer
+		err := client.ExportCSV(ctx, &buf, smysql.CSVOptions{Header: true, NullAs: `\N`}, "SELECT "+strings.Join(columns, ", ")+" FROM cities_test")
+		if err != nil {
+			t.Fatalf("ExportCSV failed: %v", err)
+		}
+
+		result, err := client.LoadData(ctx, "cities_load_test", columns, &buf, smysql.LoadDataOptions{Header: true})
+		if err != nil {
+			t.Fatalf("LoadData failed: %v", err)
+		}
+		if result.Rows != 10 {
+			t.Errorf("Expected 10 rows loaded, got %d", result.Rows)
+		}
+
+		var city struct {
+			Name       string  `db:"name"`
+			WikiDataId *string `db:"wikiDataId"`
+		}
+		found, err := client.First(&city, "SELECT name, wikiDataId FROM cities_load_test WHERE name LIKE ?", "Osaka%")
+		if err != nil || !found {
+			t.Fatalf("First failed: found=%v err=%v", found, err)
+		}
+		if city.Name != `Osaka, "JP" \ 1` || city.WikiDataId != nil {
+			t.Errorf("Unexpected loaded row: %q %v", city.Name, city.WikiDataId)
+		}
+	})
+
+	t.Run("Duplicates", func(t *testing.T) {
+		existingID, found, err := client.FirstColInt64("SELECT MIN(id) FROM cities_load_test")
+		if err != nil || !found {
+			t.Fatalf("FirstColInt64 failed: found=%v err=%v", found, err)
+		}
+		maxID, _, err := client.FirstColInt64("SELECT MAX(id) FROM cities_load_test")
+		if err != nil {
+			t.Fatalf("FirstColInt64 failed: %v", err)
+		}
+
+		data := fmt.Sprintf("%d\tDuplicate\n%d\tNew City\n", existingID, maxID+1)
+		result, err := client.LoadData(ctx, "cities_load_test", []string{"id", "name"}, strings.NewReader(data),
+			smysql.LoadDataOptions{Delimiter: '\t', OnDuplicate: smysql.LOAD_IGNORE})
+		if err != nil {
+			t.Fatalf("LoadData failed: %v", err)
+		}
+		if result.Rows != 1 || result.Warnings != 1 {
+			t.Errorf("Expected 1 row and 1 warning, got %d rows and %d warnings", result.Rows, result.Warnings)
+		}
+
+		result, err = client.LoadData(ctx, "cities_load_test", []string{"id", "name"}, strings.NewReader(fmt.Sprintf("%d\tReplaced\n", existingID)),
+			smysql.LoadDataOptions{Delimiter: '\t', OnDuplicate: smysql.LOAD_REPLACE})
+		if err != nil {
+			t.Fatalf("LoadData failed: %v", err)
+		}
+		if result.Rows != 2 {
+			t.Errorf("Expected 2 affected rows for replace, got %d", result.Rows)
+		}
+	})
+
+	t.Run("UnknownColumn", func(t *testing.T) {
+		if _, err := client.LoadData(ctx, "cities_load_test", []string{"id", "nope"}, strings.NewReader(""), smysql.LoadDataOptions{}); err == nil {
+			t.Error("Expected error for unknown column, but got none")
+		}
+	})
+}
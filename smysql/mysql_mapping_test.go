@@ -0,0 +1,378 @@
+package smysql_test
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Xuzan9396/zmysql/smysql"
+)
+
+// BaseModel 公共字段，用于测试匿名嵌入
+type BaseModel struct {
+	ID        uint      `db:"id"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// CodeRef 用于测试 prefix inline 嵌套结构体
+type CodeRef struct {
+	ID   uint   `db:"id"`
+	Code string `db:"code"`
+}
+
+// baseModel 未导出的公共字段，用于测试未导出的嵌入结构体
+type baseModel struct {
+	ID        uint      `db:"id"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// TestEmbeddedStructMapping 测试嵌入和嵌套结构体映射
+func TestEmbeddedStructMapping(t *testing.T) {
+	client, err := getTestClient()
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if err := setupTestData(client); err != nil {
+		t.Fatalf("failed to setup test data: %v", err)
+	}
+
+	t.Run("AnonymousEmbedding", func(t *testing.T) {
+		type City struct {
+			BaseModel
+			Name string `db:"name"`
+		}
+
+		var cities []City
+		if err := client.Find(&cities, "SELECT * FROM cities_test ORDER BY id"); err != nil {
+			t.Fatalf("Find failed: %v", err)
+		}
+		if len(cities) != 10 {
+			t.Fatalf("Expected 10 cities, got %d", len(cities))
+		}
+		if cities[0].ID == 0 || cities[0].CreatedAt.IsZero() {
+			t.Errorf("Expected embedded fields to be set, got %+v", cities[0])
+		}
+	})
+
+	t.Run("EmbeddedPointer", func(t *testing.T) {
+		type City struct {
+			*BaseModel
+			Name string `db:"name"`
+		}
+
+		var city City
+		found, err := client.First(&city, "SELECT * FROM cities_test WHERE name = ?", "Tokyo")
+		if err != nil || !found {
+			t.Fatalf("First failed: found=%v err=%v", found, err)
+		}
+		if city.BaseModel == nil || city.ID == 0 {
+			t.Errorf("Expected embedded pointer to be allocated, got %+v", city)
+		}
+	})
+
+	t.Run("PrefixInline", func(t *testing.T) {
+		type City struct {
+			ID      uint    `db:"id"`
+			Name    string  `db:"name"`
+			State   CodeRef `db:"state_,inline"`
+			Country CodeRef `db:"country_,inline"`
+		}
+
+		var city City
+		found, err := client.First(&city, "SELECT * FROM cities_test WHERE name = ?", "Osaka")
+		if err != nil || !found {
+			t.Fatalf("First failed: found=%v err=%v", found, err)
+		}
+		if city.State.ID != 5 || city.State.Code != "OS" || city.Country.ID != 2 || city.Country.Code != "JP" {
+			t.Errorf("Unexpected nested mapping: %+v", city)
+		}
+	})
+
+	t.Run("UnexportedEmbedding", func(t *testing.T) {
+		// 与 encoding/json 相同，未导出的非指针嵌入结构体会展开，导出字段可以赋值
+		type City struct {
+			baseModel
+			Name string `db:"name"`
+		}
+
+		var city City
+		found, err := client.First(&city, "SELECT * FROM cities_test WHERE name = ?", "Tokyo")
+		if err != nil || !found {
+			t.Fatalf("First failed: found=%v err=%v", found, err)
+		}
+		if city.ID == 0 || city.CreatedAt.IsZero() || city.Name != "Tokyo" {
+			t.Errorf("Expected unexported embedded fields to be set, got %+v", city)
+		}
+	})
+
+	t.Run("UnexportedFieldsSkipped", func(t *testing.T) {
+		// 未导出的嵌入指针和未导出的 inline 字段无法赋值，跳过而不是 panic
+		type City struct {
+			*baseModel
+			state CodeRef `db:"state_,inline"`
+			Name  string  `db:"name"`
+		}
+
+		var cities []City
+		if err := client.Find(&cities, "SELECT * FROM cities_test ORDER BY id"); err != nil {
+			t.Fatalf("Find failed: %v", err)
+		}
+		if len(cities) != 10 || cities[0].Name == "" {
+			t.Fatalf("Expected 10 named cities, got %+v", cities)
+		}
+		if cities[0].baseModel != nil || cities[0].state != (CodeRef{}) {
+			t.Errorf("Expected unexported fields to be skipped, got %+v", cities[0])
+		}
+
+		var city City
+		found, err := client.First(&city, "SELECT * FROM cities_test WHERE name = ?", "Osaka")
+		if err != nil || !found || city.Name != "Osaka" {
+			t.Fatalf("First failed: found=%v err=%v city=%+v", found, err, city)
+		}
+	})
+}
+
+// TestNullablePointerFields 测试指针字段和 sql.Scanner 字段的 NULL 处理
+func TestNullablePointerFields(t *testing.T) {
+	client, err := getTestClient()
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if err := setupTestData(client); err != nil {
+		t.Fatalf("failed to setup test data: %v", err)
+	}
+
+	_, err = client.Exec(`
+		INSERT INTO cities_test (name, state_id, state_code, country_id, country_code, latitude, longitude, flag, wikiDataId) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		"Null Pointer", 99, "NP", 99, "NP", 0.0, 0.0, false, nil)
+	if err != nil {
+		t.Fatalf("Failed to insert NULL test data: %v", err)
+	}
+
+	type City struct {
+		ID          *int64           `db:"id"`
+		Name        *string          `db:"name"`
+		Latitude    *float64         `db:"latitude"`
+		Flag        *bool            `db:"flag"`
+		CreatedAt   *time.Time       `db:"created_at"`
+		WikiDataId  *string          `db:"wikiDataId"`
+		WikiNull    sql.NullString   `db:"wiki_null"`
+		WikiGeneric sql.Null[string] `db:"wiki_generic"`
+	}
+
+	query := "SELECT *, wikiDataId AS wiki_null, wikiDataId AS wiki_generic FROM cities_test WHERE name = ?"
+
+	t.Run("NullColumn", func(t *testing.T) {
+		var city City
+		found, err := client.First(&city, query, "Null Pointer")
+		if err != nil || !found {
+			t.Fatalf("First failed: found=%v err=%v", found, err)
+		}
+		if city.WikiDataId != nil {
+			t.Errorf("Expected nil for NULL wikiDataId, got %q", *city.WikiDataId)
+		}
+		if city.WikiNull.Valid || city.WikiGeneric.Valid {
+			t.Errorf("Expected invalid sql.Null values, got %+v %+v", city.WikiNull, city.WikiGeneric)
+		}
+		if city.ID == nil || city.Name == nil || *city.Name != "Null Pointer" || city.Flag == nil || city.CreatedAt == nil {
+			t.Errorf("Expected non-NULL pointer fields to be allocated, got %+v", city)
+		}
+	})
+
+	t.Run("EmptyStringIsNotNull", func(t *testing.T) {
+		var city City
+		found, err := client.First(&city, query, "TestEmpty")
+		if err != nil || !found {
+			t.Fatalf("First failed: found=%v err=%v", found, err)
+		}
+		if city.WikiDataId == nil || *city.WikiDataId != "" {
+			t.Errorf("Expected pointer to empty string, got %v", city.WikiDataId)
+		}
+		if !city.WikiNull.Valid || !city.WikiGeneric.Valid {
+			t.Errorf("Expected valid sql.Null values, got %+v %+v", city.WikiNull, city.WikiGeneric)
+		}
+	})
+
+	t.Run("FirstColPointer", func(t *testing.T) {
+		var wiki *string
+		found, err := client.FirstCol(&wiki, "SELECT wikiDataId FROM cities_test WHERE name = ?", "Null Pointer")
+		if err != nil || !found {
+			t.Fatalf("FirstCol failed: found=%v err=%v", found, err)
+		}
+		if wiki != nil {
+			t.Errorf("Expected nil, got %q", *wiki)
+		}
+	})
+}
+
+// TestJSONColumnMapping 测试 db:"name,json" 字段的读写
+func TestJSONColumnMapping(t *testing.T) {
+	client, err := getTestClient()
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if err := setupTestData(client); err != nil {
+		t.Fatalf("failed to setup test data: %v", err)
+	}
+
+	type Attrs struct {
+		Code string `json:"code"`
+		Flag int    `json:"flag"`
+	}
+
+	type City struct {
+		ID    uint           `db:"id"`
+		Attrs Attrs          `db:"attrs,json"`
+		Extra map[string]any `db:"extra,json"`
+		Tags  []string       `db:"wikiDataId,json"`
+	}
+
+	t.Run("ReadJSON", func(t *testing.T) {
+		var cities []City
+		err := client.Find(&cities, `SELECT id, JSON_OBJECT('code', country_code, 'flag', flag) AS attrs, NULL AS extra
+			FROM cities_test WHERE country_code = ? ORDER BY id`, "JP")
+		if err != nil {
+			t.Fatalf("Find failed: %v", err)
+		}
+		if len(cities) != 2 {
+			t.Fatalf("Expected 2 cities, got %d", len(cities))
+		}
+		if cities[0].Attrs.Code != "JP" || cities[0].Attrs.Flag != 1 {
+			t.Errorf("Unexpected attrs: %+v", cities[0].Attrs)
+		}
+		if cities[0].Extra != nil {
+			t.Errorf("Expected nil map for NULL json, got %v", cities[0].Extra)
+		}
+	})
+
+	t.Run("WriteJSON", func(t *testing.T) {
+		arg := struct {
+			Name string   `db:"name"`
+			Tags []string `db:"tags,json"`
+		}{Name: "Tokyo", Tags: []string{"capital", "asia"}}
+
+		if _, err := client.ExecNamed("UPDATE cities_test SET wikiDataId = :tags WHERE name = :name", arg); err != nil {
+			t.Fatalf("ExecNamed failed: %v", err)
+		}
+
+		var city City
+		found, err := client.First(&city, "SELECT id, wikiDataId FROM cities_test WHERE name = ?", "Tokyo")
+		if err != nil || !found {
+			t.Fatalf("First failed: found=%v err=%v", found, err)
+		}
+		if len(city.Tags) != 2 || city.Tags[0] != "capital" {
+			t.Errorf("Unexpected tags: %v", city.Tags)
+		}
+	})
+}
+
+// TestSnakeCase 测试内置的蛇形命名转换
+func TestSnakeCase(t *testing.T) {
+	tests := map[string]string{
+		"CountryCode": "country_code",
+		"UserID":      "user_id",
+		"HTTPServer":  "http_server",
+		"WikiDataId":  "wiki_data_id",
+		"ID":          "id",
+	}
+	for in, want := range tests {
+		if got := smysql.SnakeCase(in); got != want {
+			t.Errorf("SnakeCase(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+// TestNameMapper 测试没有 db 标签的字段按 WithNameMapper 映射，以及列名大小写不敏感匹配
+func TestNameMapper(t *testing.T) {
+	client, err := getTestClient(smysql.WithNameMapper(smysql.SnakeCase))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if err := setupTestData(client); err != nil {
+		t.Fatalf("failed to setup test data: %v", err)
+	}
+
+	type City struct {
+		ID          uint
+		Name        string
+		CountryCode string
+		WikiDataId  string `db:"wikiDataId"`
+		Internal    string `db:"-"`
+	}
+
+	var city City
+	found, err := client.First(&city, "SELECT id, name, country_code, wikidataid, name AS Internal FROM cities_test WHERE name = ?", "Tokyo")
+	if err != nil || !found {
+		t.Fatalf("First failed: found=%v err=%v", found, err)
+	}
+	if city.ID == 0 || city.Name != "Tokyo" || city.CountryCode != "JP" {
+		t.Errorf("Unexpected mapped city: %+v", city)
+	}
+	if city.WikiDataId == "" {
+		t.Errorf("Expected wikidataid to match wikiDataId case-insensitively, got empty")
+	}
+	if city.Internal != "" {
+		t.Errorf("Expected db:\"-\" field to be skipped, got %q", city.Internal)
+	}
+}
+
+// TestStrictMapping 测试严格映射模式下报告未匹配的列和字段
+func TestStrictMapping(t *testing.T) {
+	client, err := getTestClient(smysql.WithStrictMapping())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if err := setupTestData(client); err != nil {
+		t.Fatalf("failed to setup test data: %v", err)
+	}
+
+	type City struct {
+		ID   uint   `db:"id"`
+		Name string `db:"name"`
+	}
+
+	t.Run("Matched", func(t *testing.T) {
+		var cities []City
+		if err := client.Find(&cities, "SELECT id, name FROM cities_test"); err != nil {
+			t.Errorf("Expected no error for matched columns, got %v", err)
+		}
+	})
+
+	t.Run("UnmappedColumn", func(t *testing.T) {
+		var cities []City
+		err := client.Find(&cities, "SELECT id, name, country_code FROM cities_test")
+		if err == nil || !strings.Contains(err.Error(), "country_code") {
+			t.Errorf("Expected error naming country_code, got %v", err)
+		}
+	})
+
+	t.Run("MissingColumn", func(t *testing.T) {
+		var city City
+		_, err := client.First(&city, "SELECT id FROM cities_test LIMIT 1")
+		if err == nil || !strings.Contains(err.Error(), "name") {
+			t.Errorf("Expected error naming missing field name, got %v", err)
+		}
+	})
+
+	t.Run("FindMapKeyIgnored", func(t *testing.T) {
+		type NameOnly struct {
+			Name string `db:"name"`
+		}
+		if _, err := smysql.FindMap[int64, NameOnly](client, "id", "", "SELECT id, name FROM cities_test"); err != nil {
+			t.Errorf("Expected key column to be ignored, got %v", err)
+		}
+	})
+}
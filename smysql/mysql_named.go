@@ -0,0 +1,207 @@
+package smysql
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentChar(c byte) bool {
+	return isIdentStart(c) || (c >= '0' && c <= '9')
+}
+
+// compileNamed 将 :name / @name 形式的命名参数替换为 ?，返回替换后的 SQL 和参数名顺序
+// 字符串、注释中的内容以及 :: 和 := 会被忽略；@name 只有在 has(name) 为 true 时才视为参数，
+// 否则保留为 MySQL 会话变量，@@name 系统变量始终保留
+func compileNamed(query string, has func(name string) bool) (string, []string) {
+	var sb strings.Builder
+	var names []string
+	last := 0
+	for i := 0; i < len(query); {
+		if j := skipNonCode(query, i); j > i {
+			i = j
+			continue
+		}
+
+		c := query[i]
+		if c != ':' && c != '@' {
+			i++
+			continue
+		}
+		if i+1 >= len(query) {
+			break
+		}
+		next := query[i+1]
+		if next == c || (c == ':' && next == '=') {
+			// :: 类型转换、:= 赋值、@@ 系统变量
+			i += 2
+			for c == '@' && i < len(query) && isIdentChar(query[i]) {
+				i++
+			}
+			continue
+		}
+		if !isIdentStart(next) {
+			i++
+			continue
+		}
+
+		end := i + 1
+		for end < len(query) && isIdentChar(query[end]) {
+			end++
+		}
+		name := query[i+1 : end]
+		if c == '@' && !has(name) {
+			i = end
+			continue
+		}
+
+		sb.WriteString(query[last:i])
+		sb.WriteByte('?')
+		names = append(names, name)
+		i = end
+		last = end
+	}
+	sb.WriteString(query[last:])
+	return sb.String(), names
+}
+
+// namedSource 根据 map 或结构体（db 标签）创建参数查找函数，结构体中 json 字段会编码为 JSON 字符串
+func (client *MySQLClient) namedSource(arg any) (func(name string) (any, bool, error), error) {
+	v := reflect.ValueOf(arg)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, fmt.Errorf("named arg cannot be nil")
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("named arg map key must be string")
+		}
+		return func(name string) (any, bool, error) {
+			value := v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key()))
+			if !value.IsValid() {
+				return nil, false, nil
+			}
+			return value.Interface(), true, nil
+		}, nil
+	case reflect.Struct:
+		fieldsMapping := client.getFieldsMapping(v.Type())
+		return func(name string) (any, bool, error) {
+			info, ok := fieldsMapping.lookup(name)
+			if !ok {
+				return nil, false, nil
+			}
+			field, ok := fieldByIndex(v, info.index)
+			if !ok {
+				// 嵌入的指针为 nil 时按 NULL 处理
+				return nil, true, nil
+			}
+			if info.json {
+				value, err := jsonArg(field)
+				return value, true, err
+			}
+			return field.Interface(), true, nil
+		}, nil
+	default:
+		return nil, fmt.Errorf("named arg must be a map or struct, got %s", v.Kind())
+	}
+}
+
+// bindNamed 解析命名参数 SQL 并按参数名顺序取值
+func (client *MySQLClient) bindNamed(query string, arg any) (string, []any, error) {
+	lookup, err := client.namedSource(arg)
+	if err != nil {
+		return "", nil, err
+	}
+
+	compiled, names := compileNamed(query, func(name string) bool {
+		_, ok, _ := lookup(name)
+		return ok
+	})
+
+	args := make([]any, len(names))
+	for i, name := range names {
+		value, ok, err := lookup(name)
+		if err != nil {
+			return "", nil, fmt.Errorf("named parameter '%s': %v", name, err)
+		}
+		if !ok {
+			return "", nil, fmt.Errorf("named parameter '%s' not found in arg", name)
+		}
+		args[i] = value
+	}
+	return compiled, args, nil
+}
+
+// bindNamedProc 按 paramNames 顺序从 arg 中取值，作为存储过程参数
+func (client *MySQLClient) bindNamedProc(arg any, paramNames []string) ([]any, error) {
+	lookup, err := client.namedSource(arg)
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]any, len(paramNames))
+	for i, name := range paramNames {
+		value, ok, err := lookup(name)
+		if err != nil {
+			return nil, fmt.Errorf("named parameter '%s': %v", name, err)
+		}
+		if !ok {
+			return nil, fmt.Errorf("named parameter '%s' not found in arg", name)
+		}
+		args[i] = value
+	}
+	return args, nil
+}
+
+// FindNamed 使用命名参数执行查询并将结果映射到结构体切片中，arg 为 map 或带 db 标签的结构体
+func (client *MySQLClient) FindNamed(dest any, query string, arg any) error {
+	query, args, err := client.bindNamed(query, arg)
+	if err != nil {
+		return err
+	}
+	return client.Find(dest, query, args...)
+}
+
+// FirstNamed 使用命名参数执行查询并将结果映射到结构体中，查询一条数据
+func (client *MySQLClient) FirstNamed(dest any, query string, arg any) (bool, error) {
+	query, args, err := client.bindNamed(query, arg)
+	if err != nil {
+		return false, err
+	}
+	return client.First(dest, query, args...)
+}
+
+// ExecNamed 使用命名参数执行 SQL 并返回是否成功
+func (client *MySQLClient) ExecNamed(query string, arg any) (bool, error) {
+	query, args, err := client.bindNamed(query, arg)
+	if err != nil {
+		return false, err
+	}
+	return client.Exec(query, args...)
+}
+
+// FindProcNamed 执行存储过程，参数按 paramNames 顺序从 arg 中取值
+func (client *MySQLClient) FindProcNamed(dest any, procName string, arg any, paramNames ...string) error {
+	args, err := client.bindNamedProc(arg, paramNames)
+	if err != nil {
+		return err
+	}
+	return client.FindProc(dest, procName, args...)
+}
+
+// FirstProcNamed 执行存储过程并查询一条数据，参数按 paramNames 顺序从 arg 中取值
+func (client *MySQLClient) FirstProcNamed(dest any, procName string, arg any, paramNames ...string) (bool, error) {
+	args, err := client.bindNamedProc(arg, paramNames)
+	if err != nil {
+		return false, err
+	}
+	return client.FirstProc(dest, procName, args...)
+}
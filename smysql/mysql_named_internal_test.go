@@ -0,0 +1,98 @@
+package smysql
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestCompileNamed 测试命名参数解析，字符串、注释、:: 类型转换和 @@ 系统变量中的内容不视为参数
+func TestCompileNamed(t *testing.T) {
+	params := map[string]bool{"id": true, "name": true, "x": true, "version": true}
+	has := func(name string) bool { return params[name] }
+
+	tests := []struct {
+		name      string
+		query     string
+		wantQuery string
+		wantNames []string
+	}{
+		{
+			name:      "Basic",
+			query:     "SELECT * FROM t WHERE id = :id AND name = :name OR id = :id",
+			wantQuery: "SELECT * FROM t WHERE id = ? AND name = ? OR id = ?",
+			wantNames: []string{"id", "name", "id"},
+		},
+		{
+			name:      "DoubleColonCast",
+			query:     "SELECT a::int, :id::text FROM t",
+			wantQuery: "SELECT a::int, ?::text FROM t",
+			wantNames: []string{"id"},
+		},
+		{
+			name:      "DashComment",
+			query:     "SELECT :id -- :name\nFROM t",
+			wantQuery: "SELECT ? -- :name\nFROM t",
+			wantNames: []string{"id"},
+		},
+		{
+			name:      "DashWithoutSpaceIsNotComment",
+			query:     "SELECT 5--:x",
+			wantQuery: "SELECT 5--?",
+			wantNames: []string{"x"},
+		},
+		{
+			name:      "HashComment",
+			query:     "SELECT :id # :name\n, :x",
+			wantQuery: "SELECT ? # :name\n, ?",
+			wantNames: []string{"id", "x"},
+		},
+		{
+			name:      "BlockComment",
+			query:     "SELECT /* :name, 'x */ :id /* unterminated :x",
+			wantQuery: "SELECT /* :name, 'x */ ? /* unterminated :x",
+			wantNames: []string{"id"},
+		},
+		{
+			name:      "SystemVariable",
+			query:     "SELECT @@version, @@session.time_zone, @@sql_mode, :id",
+			wantQuery: "SELECT @@version, @@session.time_zone, @@sql_mode, ?",
+			wantNames: []string{"id"},
+		},
+		{
+			name:      "SessionVariableAssign",
+			query:     "SET @v := :x, @w = @name",
+			wantQuery: "SET @v := ?, @w = ?",
+			wantNames: []string{"x", "name"},
+		},
+		{
+			name:      "AssignWithoutSpace",
+			query:     "SELECT @v:=:x",
+			wantQuery: "SELECT @v:=?",
+			wantNames: []string{"x"},
+		},
+		{
+			name:      "QuoteEscape",
+			query:     `SELECT ':id', 'it''s :id', 'a\':id', "b"":id", "c\":id", ` + "`col``:id`" + `, :name`,
+			wantQuery: `SELECT ':id', 'it''s :id', 'a\':id', "b"":id", "c\":id", ` + "`col``:id`" + `, ?`,
+			wantNames: []string{"name"},
+		},
+		{
+			name:      "TimeLiteral",
+			query:     "SELECT '12:30:00', :id",
+			wantQuery: "SELECT '12:30:00', ?",
+			wantNames: []string{"id"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, names := compileNamed(tt.query, has)
+			if query != tt.wantQuery {
+				t.Errorf("Expected query:\n%s\ngot:\n%s", tt.wantQuery, query)
+			}
+			if !reflect.DeepEqual(names, tt.wantNames) {
+				t.Errorf("Expected names %v, got %v", tt.wantNames, names)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package smysql_test
+
+import (
+	"testing"
+)
+
+// TestNamedParams 测试命名参数
+func TestNamedParams(t *testing.T) {
+	client, err := getTestClient()
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if err := setupTestData(client); err != nil {
+		t.Fatalf("failed to setup test data: %v", err)
+	}
+
+	t.Run("FindNamedWithMap", func(t *testing.T) {
+		var cities []CityTest
+		err := client.FindNamed(&cities, "SELECT * FROM cities_test WHERE country_code = :cc AND flag = :flag AND name <> ':cc'",
+			map[string]any{"cc": "JP", "flag": true})
+		if err != nil {
+			t.Fatalf("FindNamed failed: %v", err)
+		}
+		if len(cities) != 1 {
+			t.Errorf("Expected 1 city, got %d", len(cities))
+		}
+	})
+
+	t.Run("FirstNamedWithStruct", func(t *testing.T) {
+		filter := struct {
+			Name string `db:"name"`
+		}{Name: "Tokyo"}
+
+		var city CityTest
+		found, err := client.FirstNamed(&city, "SELECT * FROM cities_test WHERE name = @name", filter)
+		if err != nil {
+			t.Fatalf("FirstNamed failed: %v", err)
+		}
+		if !found || city.Name != "Tokyo" {
+			t.Errorf("Expected Tokyo, got found=%v city=%+v", found, city)
+		}
+	})
+
+	t.Run("ExecNamedWithSlice", func(t *testing.T) {
+		ok, err := client.ExecNamed("UPDATE cities_test SET flag = :flag WHERE name IN (:names)",
+			map[string]any{"flag": false, "names": []string{"Beijing", "Shanghai"}})
+		if err != nil {
+			t.Fatalf("ExecNamed failed: %v", err)
+		}
+		if !ok {
+			t.Error("Expected rows to be affected")
+		}
+	})
+
+	t.Run("MissingParam", func(t *testing.T) {
+		var cities []CityTest
+		err := client.FindNamed(&cities, "SELECT * FROM cities_test WHERE name = :name", map[string]any{})
+		if err == nil {
+			t.Error("Expected error for missing named parameter, but got none")
+		}
+	})
+}
@@ -0,0 +1,62 @@
+package smysql
+
+import (
+	"strings"
+	"unicode"
+)
+
+// WithNameMapper 设置没有 db 标签的字段的列名转换函数，可使用内置的 SnakeCase、LowerCase、ExactName
+// 未设置时没有 db 标签的字段不参与映射；db:"-" 的字段始终忽略
+func WithNameMapper(mapper func(fieldName string) string) func(*MySQLClient) {
+	return func(client *MySQLClient) {
+		client.nameMapper = mapper
+	}
+}
+
+// SnakeCase 将字段名转换为蛇形命名，如 CountryCode -> country_code，UserID -> user_id，HTTPServer -> http_server
+func SnakeCase(fieldName string) string {
+	runes := []rune(fieldName)
+	var sb strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			// 小写或数字后的大写字母、连续大写字母中后面跟小写的那个，都是新单词的开始
+			if i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+				(unicode.IsUpper(runes[i-1]) && i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				sb.WriteByte('_')
+			}
+			sb.WriteRune(unicode.ToLower(r))
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// LowerCase 将字段名转换为全小写，如 CountryCode -> countrycode
+func LowerCase(fieldName string) string {
+	return strings.ToLower(fieldName)
+}
+
+// ExactName 直接使用字段名作为列名，列名匹配本身忽略大小写
+func ExactName(fieldName string) string {
+	return fieldName
+}
+
+// CamelCase 将蛇形命名转换为小驼峰，如 country_code -> countryCode，可用于 WithJSONKeyMapper
+func CamelCase(name string) string {
+	var sb strings.Builder
+	upper := false
+	for i, r := range name {
+		if r == '_' {
+			upper = i > 0
+			continue
+		}
+		if upper {
+			sb.WriteRune(unicode.ToUpper(r))
+			upper = false
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
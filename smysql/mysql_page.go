@@ -0,0 +1,162 @@
+package smysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Page 分页结果信息
+type Page struct {
+	Total    int64 `json:"total"`
+	Page     int   `json:"page"`
+	PageSize int   `json:"page_size"`
+	HasNext  bool  `json:"has_next"`
+}
+
+// Cursor 游标分页参数，适用于 OFFSET 过大导致性能下降的深分页
+type Cursor struct {
+	Column string // 游标列，必须唯一且可排序，如 id
+	After  any    // 上一页最后一条记录的游标值，nil 表示第一页
+	Desc   bool   // 是否倒序
+}
+
+// CursorPage 游标分页结果信息
+type CursorPage struct {
+	PageSize   int  `json:"page_size"`
+	HasNext    bool `json:"has_next"`
+	NextCursor any  `json:"next_cursor"` // 下一页的 Cursor.After，没有下一页时为 nil
+}
+
+// trimQuery 去掉查询末尾的空白和分号，便于追加 LIMIT 或作为子查询
+func trimQuery(query string) string {
+	return strings.TrimRight(strings.TrimSpace(query), "; \t\r\n")
+}
+
+// countQuery 执行 SELECT COUNT(*) FROM (...) t 获取总数
+func (client *MySQLClient) countQuery(ctx context.Context, query string, args ...any) (int64, error) {
+	query, args, err := expandArgs(fmt.Sprintf("SELECT COUNT(*) FROM (%s) t", trimQuery(query)), args)
+	if err != nil {
+		return 0, err
+	}
+	client.debugLog(query, args...)
+
+	var total sql.NullInt64
+	if err := client.DB.QueryRowContext(ctx, query, args...).Scan(&total); err != nil {
+		return 0, fmt.Errorf("failed to count query: %v", err)
+	}
+	return total.Int64, nil
+}
+
+// Paginate 分页查询，page 从 1 开始，dest 必须为切片指针
+// 数据查询追加 LIMIT/OFFSET，总数通过 SELECT COUNT(*) FROM (query) t 获取，query 本身不应包含 LIMIT
+func (client *MySQLClient) Paginate(ctx context.Context, dest any, query string, page, pageSize int, args ...any) (*Page, error) {
+	destValue := reflect.ValueOf(dest)
+	if destValue.Kind() != reflect.Ptr || destValue.Elem().Kind() != reflect.Slice {
+		return nil, fmt.Errorf("dest must be a pointer to a slice")
+	}
+	if pageSize <= 0 {
+		return nil, fmt.Errorf("pageSize must be greater than 0")
+	}
+	if page < 1 {
+		page = 1
+	}
+
+	total, err := client.countQuery(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Page{
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+		HasNext:  int64(page)*int64(pageSize) < total,
+	}
+
+	offset := int64(page-1) * int64(pageSize)
+	if offset >= total {
+		// 超出范围时不再执行数据查询
+		destValue.Elem().Set(reflect.MakeSlice(destValue.Elem().Type(), 0, 0))
+		return result, nil
+	}
+
+	dataQuery := fmt.Sprintf("%s LIMIT %d OFFSET %d", trimQuery(query), pageSize, offset)
+	if err := client.find(ctx, dest, dataQuery, args...); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PaginateCursor 游标分页查询，按 cursor.Column 排序并取 cursor.After 之后的 pageSize 条数据
+// 游标条件、ORDER BY 和 LIMIT 直接加入 b 生成的查询，可以使用 cursor.Column 上的索引，b 本身不能包含 ORDER BY/LIMIT/OFFSET
+// cursor.Column 必须是表中的列而不是别名或聚合结果，多表查询时可以写为 t.id，b 不会被修改
+func (client *MySQLClient) PaginateCursor(ctx context.Context, dest any, b *SelectBuilder, cursor Cursor, pageSize int) (*CursorPage, error) {
+	destValue := reflect.ValueOf(dest)
+	if destValue.Kind() != reflect.Ptr || destValue.Elem().Kind() != reflect.Slice {
+		return nil, fmt.Errorf("dest must be a pointer to a slice")
+	}
+	if b == nil {
+		return nil, fmt.Errorf("select builder cannot be nil")
+	}
+	if len(b.orderBy) > 0 || b.limit >= 0 || b.offset >= 0 {
+		return nil, fmt.Errorf("select builder cannot have ORDER BY, LIMIT or OFFSET for cursor pagination")
+	}
+	if pageSize <= 0 {
+		return nil, fmt.Errorf("pageSize must be greater than 0")
+	}
+	if !identPattern.MatchString(cursor.Column) {
+		return nil, fmt.Errorf("invalid cursor column '%s'", cursor.Column)
+	}
+
+	elemType := destValue.Elem().Type().Elem()
+	if elemType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("dest must be a pointer to a slice of struct")
+	}
+	fieldName := cursor.Column[strings.LastIndex(cursor.Column, ".")+1:]
+	cursorField, ok := client.getFieldsMapping(elemType).lookup(fieldName)
+	if !ok {
+		return nil, fmt.Errorf("cursor column '%s' is not mapped in %s", cursor.Column, elemType)
+	}
+
+	op, order := ">", "ASC"
+	if cursor.Desc {
+		op, order = "<", "DESC"
+	}
+
+	// 复制构建器，原有条件整体作为一组，避免 OR 条件与游标条件的优先级问题
+	q := *b
+	q.wheres = nil
+	if len(b.wheres) > 0 {
+		q.wheres = append(q.wheres, whereItem{op: "AND", cond: whereGroup(b.wheres)})
+	}
+	if cursor.After != nil {
+		q.wheres = append(q.wheres, whereItem{op: "AND", cond: Expr(quoteIdent(cursor.Column)+" "+op+" ?", cursor.After)})
+	}
+	// 多取一条用于判断是否还有下一页
+	q.orderBy = []string{cursor.Column + " " + order}
+	q.limit = pageSize + 1
+
+	query, args, err := q.ToSQL()
+	if err != nil {
+		return nil, err
+	}
+	if err := client.find(ctx, dest, query, args...); err != nil {
+		return nil, err
+	}
+
+	result := &CursorPage{PageSize: pageSize}
+	rows := destValue.Elem()
+	if rows.Len() > pageSize {
+		rows.Set(rows.Slice(0, pageSize))
+		result.HasNext = true
+	}
+	if result.HasNext {
+		if field, ok := fieldByIndex(rows.Index(rows.Len()-1), cursorField.index); ok {
+			result.NextCursor = field.Interface()
+		}
+	}
+	return result, nil
+}
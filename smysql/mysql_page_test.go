@@ -0,0 +1,84 @@
+package smysql_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Xuzan9396/zmysql/smysql"
+)
+
+// TestPaginate 测试分页查询
+func TestPaginate(t *testing.T) {
+	client, err := getTestClient()
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if err := setupTestData(client); err != nil {
+		t.Fatalf("failed to setup test data: %v", err)
+	}
+
+	ctx := context.Background()
+
+	t.Run("OffsetPages", func(t *testing.T) {
+		var cities []CityTest
+		page, err := client.Paginate(ctx, &cities, "SELECT * FROM cities_test ORDER BY id", 1, 4)
+		if err != nil {
+			t.Fatalf("Paginate failed: %v", err)
+		}
+		if page.Total != 10 || len(cities) != 4 || !page.HasNext {
+			t.Errorf("Unexpected first page: %+v, rows=%d", page, len(cities))
+		}
+
+		page, err = client.Paginate(ctx, &cities, "SELECT * FROM cities_test ORDER BY id", 3, 4)
+		if err != nil {
+			t.Fatalf("Paginate failed: %v", err)
+		}
+		if len(cities) != 2 || page.HasNext {
+			t.Errorf("Unexpected last page: %+v, rows=%d", page, len(cities))
+		}
+
+		page, err = client.Paginate(ctx, &cities, "SELECT * FROM cities_test WHERE country_code = ?", 5, 4, "CN")
+		if err != nil {
+			t.Fatalf("Paginate out of range failed: %v", err)
+		}
+		if page.Total != 4 || len(cities) != 0 {
+			t.Errorf("Unexpected out of range page: %+v, rows=%d", page, len(cities))
+		}
+	})
+
+	t.Run("CursorPages", func(t *testing.T) {
+		// OR 条件与游标条件组合时保持原有条件的优先级
+		b := smysql.Select().From("cities_test").Where("flag = ?", true).OrWhere("country_code = ?", "JP")
+		cursor := smysql.Cursor{Column: "id"}
+		seen := 0
+		for i := 0; i < 10; i++ {
+			var cities []CityTest
+			page, err := client.PaginateCursor(ctx, &cities, b, cursor, 3)
+			if err != nil {
+				t.Fatalf("PaginateCursor failed: %v", err)
+			}
+			seen += len(cities)
+			if !page.HasNext {
+				break
+			}
+			cursor.After = page.NextCursor
+		}
+		if seen != 9 {
+			t.Errorf("Expected 9 cities across cursor pages, got %d", seen)
+		}
+		if sql, _, _ := b.ToSQL(); strings.Contains(sql, "LIMIT") {
+			t.Errorf("Expected builder to be unchanged, got %s", sql)
+		}
+	})
+
+	t.Run("CursorRejectsOrderBy", func(t *testing.T) {
+		var cities []CityTest
+		b := smysql.Select().From("cities_test").OrderBy("name")
+		if _, err := client.PaginateCursor(ctx, &cities, b, smysql.Cursor{Column: "id"}, 3); err == nil {
+			t.Error("Expected error for builder with ORDER BY, but got none")
+		}
+	})
+}
@@ -0,0 +1,105 @@
+package smysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// WithAllowedProc 将存储过程加入白名单，paramCount 为参数个数，小于 0 时不校验参数个数
+// 设置白名单后只允许调用白名单中的存储过程，且不再查询 information_schema
+func WithAllowedProc(procName string, paramCount int) func(*MySQLClient) {
+	return func(client *MySQLClient) {
+		client.allowedProcs[strings.ToLower(procName)] = paramCount
+	}
+}
+
+// WithProcValidation 调用存储过程前通过 information_schema.ROUTINES 校验存储过程是否存在以及参数个数，结果会缓存
+func WithProcValidation() func(*MySQLClient) {
+	return func(client *MySQLClient) {
+		client.validateProcs = true
+	}
+}
+
+// splitProcName 拆分 schema.proc 形式的存储过程名，名称为空或包含空的部分时返回错误
+func splitProcName(procName string) (schema, name string, err error) {
+	parts := strings.Split(procName, ".")
+	if len(parts) > 2 {
+		return "", "", fmt.Errorf("invalid procedure name '%s'", procName)
+	}
+	for _, part := range parts {
+		if strings.TrimSpace(part) == "" {
+			return "", "", fmt.Errorf("invalid procedure name '%s'", procName)
+		}
+	}
+	if len(parts) == 2 {
+		return parts[0], parts[1], nil
+	}
+	return "", parts[0], nil
+}
+
+// checkProc 校验存储过程名称，并按白名单或 information_schema 校验存储过程是否存在以及参数个数
+func (client *MySQLClient) checkProc(ctx context.Context, procName string, argCount int) error {
+	if _, _, err := splitProcName(procName); err != nil {
+		return err
+	}
+
+	paramCount, err := client.procParamCount(ctx, procName)
+	if err != nil {
+		return err
+	}
+	if paramCount >= 0 && paramCount != argCount {
+		return fmt.Errorf("procedure '%s' expects %d arguments, got %d", procName, paramCount, argCount)
+	}
+	return nil
+}
+
+// procParamCount 获取存储过程的参数个数，优先使用白名单，未开启校验时返回 -1
+func (client *MySQLClient) procParamCount(ctx context.Context, procName string) (int, error) {
+	key := strings.ToLower(procName)
+
+	client.mu.RLock()
+	hasAllowlist := len(client.allowedProcs) > 0
+	count, ok := client.allowedProcs[key]
+	if !ok && !hasAllowlist {
+		count, ok = client.procsCache[key]
+	}
+	client.mu.RUnlock()
+	if ok {
+		return count, nil
+	}
+	if hasAllowlist {
+		return 0, fmt.Errorf("procedure '%s' is not allowed", procName)
+	}
+	if !client.validateProcs {
+		return -1, nil
+	}
+
+	schema, name, _ := splitProcName(procName)
+	schemaExpr := "DATABASE()"
+	var args []any
+	if schema != "" {
+		schemaExpr = "?"
+		args = append(args, schema)
+	}
+	args = append(args, name)
+	query := fmt.Sprintf(`SELECT COUNT(p.ORDINAL_POSITION) FROM information_schema.ROUTINES r
+		LEFT JOIN information_schema.PARAMETERS p ON p.SPECIFIC_SCHEMA = r.ROUTINE_SCHEMA AND p.SPECIFIC_NAME = r.ROUTINE_NAME AND p.ROUTINE_TYPE = 'PROCEDURE'
+		WHERE r.ROUTINE_SCHEMA = %s AND r.ROUTINE_NAME = ? AND r.ROUTINE_TYPE = 'PROCEDURE'
+		GROUP BY r.ROUTINE_SCHEMA, r.ROUTINE_NAME`, schemaExpr)
+	client.debugLog(query, args...)
+
+	err := client.DB.QueryRowContext(ctx, query, args...).Scan(&count)
+	if err == sql.ErrNoRows {
+		return 0, fmt.Errorf("procedure '%s' not found", procName)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to query procedure parameters: %v", err)
+	}
+
+	client.mu.Lock()
+	client.procsCache[key] = count
+	client.mu.Unlock()
+	return count, nil
+}
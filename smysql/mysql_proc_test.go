@@ -0,0 +1,75 @@
+package smysql_test
+
+import (
+	"testing"
+
+	"github.com/Xuzan9396/zmysql/smysql"
+)
+
+// TestProcValidation 测试存储过程名称转义、白名单和参数个数校验
+func TestProcValidation(t *testing.T) {
+	client, err := getTestClient(smysql.WithProcValidation())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if err := setupTestData(client); err != nil {
+		t.Fatalf("failed to setup test data: %v", err)
+	}
+
+	if _, err := client.DB.Exec("DROP PROCEDURE IF EXISTS cities_by_country_test"); err != nil {
+		t.Fatalf("failed to drop procedure: %v", err)
+	}
+	_, err = client.DB.Exec(`CREATE PROCEDURE cities_by_country_test(IN p_country CHAR(2))
+BEGIN
+	SELECT * FROM cities_test WHERE country_code = p_country;
+END`)
+	if err != nil {
+		t.Fatalf("failed to create procedure: %v", err)
+	}
+	defer client.DB.Exec("DROP PROCEDURE IF EXISTS cities_by_country_test")
+
+	t.Run("SchemaQualified", func(t *testing.T) {
+		var cities []CityTest
+		if err := client.FindProc(&cities, "weather.cities_by_country_test", "JP"); err != nil {
+			t.Fatalf("FindProc failed: %v", err)
+		}
+		if len(cities) != 2 {
+			t.Errorf("Expected 2 cities, got %d", len(cities))
+		}
+	})
+
+	t.Run("ArgumentCount", func(t *testing.T) {
+		var cities []CityTest
+		if err := client.FindProc(&cities, "cities_by_country_test", "JP", 1); err == nil {
+			t.Error("Expected error for argument count mismatch, but got none")
+		}
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		var cities []CityTest
+		if err := client.FindProc(&cities, "cities_by_country_test`; DROP TABLE cities_test; --", "JP"); err == nil {
+			t.Error("Expected error for unknown procedure, but got none")
+		}
+	})
+
+	t.Run("Allowlist", func(t *testing.T) {
+		limited, err := getTestClient(smysql.WithAllowedProc("cities_by_country_test", 1))
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+		defer limited.Close()
+
+		var cities []CityTest
+		if err := limited.FindProc(&cities, "cities_by_country_test", "CN"); err != nil {
+			t.Errorf("FindProc with allowed procedure failed: %v", err)
+		}
+		if err := limited.FindProc(&cities, "cities_count_test", "CN"); err == nil {
+			t.Error("Expected error for procedure outside allowlist, but got none")
+		}
+		if err := limited.FindProc(&cities, "cities_by_country_test"); err == nil {
+			t.Error("Expected error for argument count mismatch, but got none")
+		}
+	})
+}
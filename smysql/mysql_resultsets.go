@@ -0,0 +1,203 @@
+package smysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+)
+
+// WithMultiStatements 允许 QueryResultSets、ExecByteMulti 一次执行多条以分号分隔的语句
+// 会单独打开一个开启 multiStatements 的连接池，只用于这两个方法，其他查询不受影响
+// 服务端预处理不支持多条语句，多条语句的查询不能使用 ? 参数
+func WithMultiStatements() func(*MySQLClient) {
+	return func(client *MySQLClient) {
+		client.multiStatements = true
+	}
+}
+
+// ResultSets 多结果集游标，按顺序读取存储过程或多语句查询返回的结果集，可根据列信息决定如何映射
+//
+//	sets, err := client.ProcResultSets(ctx, "proc", 1)
+//	defer sets.Close()
+//	for sets.NextSet() {
+//		columns, _ := sets.Columns()
+//		...
+//	}
+//	err = sets.Err()
+type ResultSets struct {
+	client  *MySQLClient
+	stmt    *sql.Stmt
+	rows    *sql.Rows
+	index   int
+	started bool
+	err     error
+}
+
+// QueryResultSets 执行查询并返回多结果集游标，多条语句需要开启 WithMultiStatements 且不能使用 ? 参数
+func (client *MySQLClient) QueryResultSets(ctx context.Context, query string, args ...any) (*ResultSets, error) {
+	query, args, err := expandArgs(query, args)
+	if err != nil {
+		return nil, err
+	}
+	client.debugLog(query, args...)
+
+	db := client.DB
+	if client.multiDB != nil {
+		db = client.multiDB
+	}
+	// 没有参数时驱动直接执行，有参数时使用服务端预处理
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %v", err)
+	}
+	return &ResultSets{client: client, rows: rows, index: -1}, nil
+}
+
+// ProcResultSets 执行存储过程并返回多结果集游标
+func (client *MySQLClient) ProcResultSets(ctx context.Context, procName string, args ...any) (*ResultSets, error) {
+	client.debugLog(procName, args...)
+
+	query, args, err := client.procQuery(procName, args)
+	if err != nil {
+		return nil, err
+	}
+
+	stmt, err := client.DB.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare query: %v", err)
+	}
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		stmt.Close()
+		return nil, fmt.Errorf("failed to execute query: %v", err)
+	}
+	return &ResultSets{client: client, stmt: stmt, rows: rows, index: -1}, nil
+}
+
+// NextSet 移动到下一个结果集，第一次调用移动到第一个结果集，没有更多结果集时返回 false
+// 当前结果集未读取的行会被丢弃，不返回列的语句（如 UPDATE）会被跳过
+func (r *ResultSets) NextSet() bool {
+	if r.err != nil {
+		return false
+	}
+	if !r.started {
+		r.started = true
+		columns, err := r.rows.Columns()
+		if err != nil {
+			r.err = fmt.Errorf("failed to get columns: %v", err)
+			return false
+		}
+		if len(columns) > 0 {
+			r.index++
+			return true
+		}
+	}
+	if !r.rows.NextResultSet() {
+		if err := r.rows.Err(); err != nil {
+			r.err = fmt.Errorf("rows iteration error: %v", err)
+		}
+		return false
+	}
+	r.index++
+	return true
+}
+
+// Index 当前结果集的序号，从 0 开始
+func (r *ResultSets) Index() int {
+	return r.index
+}
+
+// Columns 当前结果集的列名
+func (r *ResultSets) Columns() ([]string, error) {
+	if err := r.current(); err != nil {
+		return nil, err
+	}
+	columns, err := r.rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get columns: %v", err)
+	}
+	return columns, nil
+}
+
+// ColumnTypes 当前结果集的列类型信息
+func (r *ResultSets) ColumnTypes() ([]*sql.ColumnType, error) {
+	if err := r.current(); err != nil {
+		return nil, err
+	}
+	columnTypes, err := r.rows.ColumnTypes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get column types: %v", err)
+	}
+	return columnTypes, nil
+}
+
+// ScanAll 将当前结果集的所有行映射到 dest，dest 为结构体切片指针
+func (r *ResultSets) ScanAll(dest any) error {
+	if err := r.current(); err != nil {
+		return err
+	}
+	destValue := reflect.ValueOf(dest)
+	if destValue.Kind() != reflect.Ptr || destValue.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("dest must be a pointer to a slice")
+	}
+	if err := r.client.scanRows(r.rows, destValue, destValue.Elem().Type().Elem()); err != nil {
+		return fmt.Errorf("failed to scan result set %d: %v", r.index, err)
+	}
+	return nil
+}
+
+// ScanOne 将当前结果集的第一行映射到 dest，dest 为结构体指针，没有数据时返回 false
+func (r *ResultSets) ScanOne(dest any) (bool, error) {
+	if err := r.current(); err != nil {
+		return false, err
+	}
+	destValue := reflect.ValueOf(dest)
+	if destValue.Kind() != reflect.Ptr || destValue.Elem().Kind() != reflect.Struct {
+		return false, fmt.Errorf("dest must be a pointer to a struct")
+	}
+	found, err := r.client.scanFirstRow(r.rows, destValue)
+	if err != nil {
+		return false, fmt.Errorf("failed to scan result set %d: %v", r.index, err)
+	}
+	return found, nil
+}
+
+// Skip 丢弃当前结果集剩余的行
+func (r *ResultSets) Skip() error {
+	if err := r.current(); err != nil {
+		return err
+	}
+	for r.rows.Next() {
+	}
+	if err := r.rows.Err(); err != nil {
+		return fmt.Errorf("rows iteration error: %v", err)
+	}
+	return nil
+}
+
+// Err 返回遍历结果集过程中的错误
+func (r *ResultSets) Err() error {
+	return r.err
+}
+
+// Close 关闭游标，释放连接
+func (r *ResultSets) Close() error {
+	err := r.rows.Close()
+	if r.stmt != nil {
+		r.stmt.Close()
+	}
+	return err
+}
+
+// current 检查是否已通过 NextSet 移动到某个结果集
+func (r *ResultSets) current() error {
+	if r.err != nil {
+		return r.err
+	}
+	if r.index < 0 {
+		return fmt.Errorf("no current result set, call NextSet first")
+	}
+	return nil
+}
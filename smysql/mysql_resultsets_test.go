@@ -0,0 +1,139 @@
+package smysql_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Xuzan9396/zmysql/smysql"
+)
+
+// TestResultSets 测试多结果集游标
+func TestResultSets(t *testing.T) {
+	client, err := getTestClient(smysql.WithMultiStatements())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if err := setupTestData(client); err != nil {
+		t.Fatalf("failed to setup test data: %v", err)
+	}
+
+	type CountResult struct {
+		Total int64 `db:"total"`
+	}
+
+	ctx := context.Background()
+
+	t.Run("MultiStatement", func(t *testing.T) {
+		sets, err := client.QueryResultSets(ctx, `
+			SELECT * FROM cities_test WHERE country_code = 'CN';
+			UPDATE cities_test SET flag = flag WHERE id = 0;
+			SELECT COUNT(*) AS total FROM cities_test WHERE country_code = 'JP';
+			SELECT name FROM cities_test`)
+		if err != nil {
+			t.Fatalf("QueryResultSets failed: %v", err)
+		}
+		defer sets.Close()
+
+		var cities []CityTest
+		var count CountResult
+		found := false
+		seen := 0
+		for sets.NextSet() {
+			seen++
+			columns, err := sets.Columns()
+			if err != nil {
+				t.Fatalf("Columns failed: %v", err)
+			}
+			switch {
+			case len(columns) == 1 && columns[0] == "total":
+				if found, err = sets.ScanOne(&count); err != nil {
+					t.Fatalf("ScanOne failed: %v", err)
+				}
+			case len(columns) > 1:
+				if err := sets.ScanAll(&cities); err != nil {
+					t.Fatalf("ScanAll failed: %v", err)
+				}
+			default:
+				if err := sets.Skip(); err != nil {
+					t.Fatalf("Skip failed: %v", err)
+				}
+			}
+		}
+		if err := sets.Err(); err != nil {
+			t.Fatalf("ResultSets error: %v", err)
+		}
+
+		if seen != 3 {
+			t.Errorf("Expected 3 result sets, got %d", seen)
+		}
+		if len(cities) != 4 {
+			t.Errorf("Expected 4 cities, got %d", len(cities))
+		}
+		if !found || count.Total != 2 {
+			t.Errorf("Expected total 2, got %d (found=%v)", count.Total, found)
+		}
+	})
+
+	t.Run("Procedure", func(t *testing.T) {
+		if _, err := client.DB.Exec("DROP PROCEDURE IF EXISTS cities_sets_test"); err != nil {
+			t.Fatalf("failed to drop procedure: %v", err)
+		}
+		_, err := client.DB.Exec(`CREATE PROCEDURE cities_sets_test(IN p_country CHAR(2))
+BEGIN
+	SELECT * FROM cities_test WHERE country_code = p_country;
+	SELECT COUNT(*) AS total FROM cities_test;
+END`)
+		if err != nil {
+			t.Fatalf("failed to create procedure: %v", err)
+		}
+		defer client.DB.Exec("DROP PROCEDURE IF EXISTS cities_sets_test")
+
+		sets, err := client.ProcResultSets(ctx, "cities_sets_test", "US")
+		if err != nil {
+			t.Fatalf("ProcResultSets failed: %v", err)
+		}
+		defer sets.Close()
+
+		if !sets.NextSet() {
+			t.Fatalf("Expected first result set, err: %v", sets.Err())
+		}
+		if err := sets.Skip(); err != nil {
+			t.Fatalf("Skip failed: %v", err)
+		}
+		if !sets.NextSet() {
+			t.Fatalf("Expected second result set, err: %v", sets.Err())
+		}
+		var count CountResult
+		if _, err := sets.ScanOne(&count); err != nil {
+			t.Fatalf("ScanOne failed: %v", err)
+		}
+		if count.Total == 0 {
+			t.Error("Expected non-zero total")
+		}
+		if sets.NextSet() {
+			t.Errorf("Expected no more result sets, got index %d", sets.Index())
+		}
+	})
+
+	t.Run("SharedPoolUnaffected", func(t *testing.T) {
+		// 多语句只在单独的连接池中开启
+		if _, err := client.DB.Exec("SELECT 1; SELECT 2"); err == nil {
+			t.Error("Expected multi statements to be rejected on the shared pool, but got none")
+		}
+	})
+
+	t.Run("NoCurrentSet", func(t *testing.T) {
+		sets, err := client.QueryResultSets(ctx, "SELECT 1 AS total")
+		if err != nil {
+			t.Fatalf("QueryResultSets failed: %v", err)
+		}
+		defer sets.Close()
+
+		var count CountResult
+		if _, err := sets.ScanOne(&count); err == nil {
+			t.Error("Expected error before NextSet, but got none")
+		}
+	})
+}
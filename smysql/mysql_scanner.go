@@ -0,0 +1,134 @@
+package smysql
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+var (
+	scannerType  = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// isNullableElem 判断指针指向的类型是否使用 ptrScanner 处理，即基本类型、time.Time 和注册类型
+func isNullableElem(t reflect.Type) bool {
+	if t == timeType {
+		return true
+	}
+	if codec := lookupCodec(t); codec != nil && codec.decode != nil {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+// ptrScanner 指针字段扫描器，NULL 时 valid 为 false，字段置为 nil，否则由 inner 扫描后分配指针
+type ptrScanner struct {
+	valid bool
+	inner any
+}
+
+// Scan 实现 sql.Scanner 接口
+func (s *ptrScanner) Scan(src any) error {
+	s.valid = src != nil
+	if !s.valid {
+		return nil
+	}
+	if err := s.inner.(sql.Scanner).Scan(src); err != nil {
+		return err
+	}
+	// 零值日期 0000-00-00 与 NULL 相同，*time.Time 字段置为 nil
+	if t, ok := s.inner.(*timeScanner); ok && !t.valid {
+		s.valid = false
+	}
+	return nil
+}
+
+// jsonScanner JSON 列扫描器，保存原始 JSON 数据，NULL 时 data 为 nil
+type jsonScanner struct {
+	data []byte
+}
+
+// Scan 实现 sql.Scanner 接口
+func (s *jsonScanner) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		s.data = nil
+	case []byte:
+		s.data = append([]byte(nil), v...)
+	case string:
+		s.data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into json field", src)
+	}
+	return nil
+}
+
+// jsonArg 将 json 字段编码为查询参数，nil 的 map、slice、指针写入 NULL
+func jsonArg(field reflect.Value) (any, error) {
+	switch field.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Ptr, reflect.Interface:
+		if field.IsNil() {
+			return nil, nil
+		}
+	}
+	data, err := json.Marshal(field.Interface())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json: %v", err)
+	}
+	return string(data), nil
+}
+
+// isNullScan 判断扫描器扫描到的是否为 NULL
+func isNullScan(scanner any) bool {
+	switch s := scanner.(type) {
+	case *sql.NullString:
+		return !s.Valid
+	case *sql.NullInt64:
+		return !s.Valid
+	case *sql.Null[uint64]:
+		return !s.Valid
+	case *sql.NullFloat64:
+		return !s.Valid
+	case *sql.NullBool:
+		return !s.Valid
+	case *timeScanner:
+		return !s.valid
+	case *durationScanner:
+		return !s.valid
+	case *jsonScanner:
+		return s.data == nil
+	case *codecScanner:
+		return !s.valid
+	case *ptrScanner:
+		return !s.valid
+	}
+	// reflect.New 创建的 **T 或指针字段，NULL 时为 nil
+	if v := reflect.ValueOf(scanner); v.Kind() == reflect.Ptr {
+		if elem := v.Elem(); elem.Kind() == reflect.Ptr || elem.Kind() == reflect.Interface {
+			return elem.IsNil()
+		}
+	}
+	return false
+}
+
+// scanColumnValue 将扫描器中的值转换为 T，valid 为 false 表示 NULL
+func scanColumnValue[T any](client *MySQLClient, scanner any, column string) (value T, valid bool, err error) {
+	if isNullScan(scanner) {
+		return value, false, nil
+	}
+	v := reflect.ValueOf(&value).Elem()
+	if err := client.setFieldFromNullScanner(v, scanner, v.Type()); err != nil {
+		return value, true, fmt.Errorf("failed to set column %s: %v", column, err)
+	}
+	return value, true, nil
+}
@@ -0,0 +1,103 @@
+package smysql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// StreamJSON 执行查询并将结果以 JSON 数组逐行写入 w，不在内存中保存整个结果集，值的转换与 ExecByte 相同
+// ctx 取消或写入失败时立即停止并返回错误，此时 w 中是不完整的 JSON
+func (client *MySQLClient) StreamJSON(ctx context.Context, w io.Writer, query string, args ...any) error {
+	return client.streamRows(ctx, query, args, func(rows *sql.Rows) error {
+		return client.writeRows(ctx, w, rows, false)
+	})
+}
+
+// StreamNDJSON 执行查询并将结果按 NDJSON（每行一个 JSON 对象）逐行写入 w
+// ctx 取消或写入失败时立即停止并返回错误，已写入的行都是完整的
+func (client *MySQLClient) StreamNDJSON(ctx context.Context, w io.Writer, query string, args ...any) error {
+	return client.streamRows(ctx, query, args, func(rows *sql.Rows) error {
+		return client.writeRows(ctx, w, rows, true)
+	})
+}
+
+// streamRows 执行查询并将结果集交给 fn 处理
+func (client *MySQLClient) streamRows(ctx context.Context, query string, args []any, fn func(rows *sql.Rows) error) error {
+	query, args, err := expandArgs(query, args)
+	if err != nil {
+		return err
+	}
+	client.debugLog(query, args...)
+
+	stmt, err := client.DB.PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("failed to prepare query: %v", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %v", err)
+	}
+	defer rows.Close()
+
+	return fn(rows)
+}
+
+// writeRows 逐行编码并写入 w，ndjson 为 true 时每行一个对象，否则输出为 JSON 数组
+func (client *MySQLClient) writeRows(ctx context.Context, w io.Writer, rows *sql.Rows, ndjson bool) error {
+	encoder, err := client.newRowEncoder(rows)
+	if err != nil {
+		return err
+	}
+
+	if !ndjson {
+		if _, err := io.WriteString(w, "["); err != nil {
+			return fmt.Errorf("failed to write: %v", err)
+		}
+	}
+
+	first := true
+	for rows.Next() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		rowMap, err := encoder.scan(rows)
+		if err != nil {
+			return err
+		}
+		data, err := json.Marshal(rowMap)
+		if err != nil {
+			return fmt.Errorf("failed to marshal row: %v", err)
+		}
+
+		// 每行一次写入，NDJSON 中断时不会留下半行
+		switch {
+		case ndjson:
+			data = append(data, '\n')
+		case !first:
+			data = append([]byte{','}, data...)
+		}
+		if _, err := w.Write(data); err != nil {
+			return fmt.Errorf("failed to write: %v", err)
+		}
+		first = false
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("rows iteration error: %v", err)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if !ndjson {
+		if _, err := io.WriteString(w, "]"); err != nil {
+			return fmt.Errorf("failed to write: %v", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package smysql
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/Xuzan9396/zlog"
+)
+
+type MAPPING_MODE int8
+
+const (
+	MAPPING_LOOSE  MAPPING_MODE = iota // 默认，未匹配的列和字段静默忽略
+	MAPPING_WARN                       // 通过日志警告未匹配的列和字段
+	MAPPING_STRICT                     // 存在未匹配的列或字段时返回错误
+)
+
+// WithStrictMapping 启用严格映射，结果集的列没有对应字段，或者带 db 标签的字段没有对应列时返回错误
+func WithStrictMapping() func(*MySQLClient) {
+	return func(client *MySQLClient) {
+		client.mappingMode = MAPPING_STRICT
+	}
+}
+
+// WithMappingWarn 结果集的列与结构体字段不匹配时通过日志警告，不中断查询
+func WithMappingWarn() func(*MySQLClient) {
+	return func(client *MySQLClient) {
+		client.mappingMode = MAPPING_WARN
+	}
+}
+
+// checkMapping 按 mappingMode 检查结果集的列与结构体字段是否一一对应，ignore 中的列不要求有对应字段
+func (client *MySQLClient) checkMapping(t reflect.Type, columns []string, fieldsMapping *fieldsMapping, ignore ...string) error {
+	if client.mappingMode == MAPPING_LOOSE {
+		return nil
+	}
+
+	var unmappedColumns, missingFields []string
+	matched := make(map[*fieldInfo]bool, len(columns))
+	for _, col := range columns {
+		if info, ok := fieldsMapping.lookup(col); ok {
+			matched[info] = true
+			continue
+		}
+		isIgnored := false
+		for _, name := range ignore {
+			if strings.EqualFold(col, name) {
+				isIgnored = true
+				break
+			}
+		}
+		if !isIgnored {
+			unmappedColumns = append(unmappedColumns, col)
+		}
+	}
+	for col, info := range fieldsMapping.columns {
+		if !matched[info] {
+			missingFields = append(missingFields, col)
+		}
+	}
+	if len(unmappedColumns) == 0 && len(missingFields) == 0 {
+		return nil
+	}
+	sort.Strings(missingFields)
+
+	var problems []string
+	if len(unmappedColumns) > 0 {
+		problems = append(problems, fmt.Sprintf("columns without field: %s", strings.Join(unmappedColumns, ", ")))
+	}
+	if len(missingFields) > 0 {
+		problems = append(problems, fmt.Sprintf("fields without column: %s", strings.Join(missingFields, ", ")))
+	}
+	msg := fmt.Sprintf("mapping mismatch for %s: %s", t, strings.Join(problems, "; "))
+
+	if client.mappingMode == MAPPING_WARN {
+		zlog.F("sql").Warnf("%s", msg)
+		return nil
+	}
+	return fmt.Errorf("%s", msg)
+}
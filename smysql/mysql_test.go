@@ -25,8 +25,8 @@ type CityTest struct {
 }
 
 // 获取测试客户端
-func getTestClient() (*smysql.MySQLClient, error) {
-	return smysql.Conn("root", "123456", "127.0.0.1:3326", "weather", smysql.WithDebug())
+func getTestClient(opts ...func(*smysql.MySQLClient)) (*smysql.MySQLClient, error) {
+	return smysql.Conn("root", "123456", "127.0.0.1:3326", "weather", append([]func(*smysql.MySQLClient){smysql.WithDebug()}, opts...)...)
 }
 
 // 初始化测试数据
@@ -625,9 +625,8 @@ func TestExecByte(t *testing.T) {
 			t.Fatalf("Failed to unmarshal result: %v", err)
 		}
 
-		// ExecByte 返回的是原始数据，可能是 base64 编码或其他格式
-		if result["name"] == nil {
-			t.Error("Expected name field in result")
+		if result["name"] != "Beijing" {
+			t.Errorf("Expected name Beijing, got %v", result["name"])
 		}
 
 		t.Logf("ExecByte one result: %s", string(data))
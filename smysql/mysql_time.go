@@ -0,0 +1,162 @@
+package smysql
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timeLayouts DATETIME/TIMESTAMP/DATE 的文本格式，驱动未解析为 time.Time 时（如字符串表达式、存储过程结果）使用
+var timeLayouts = []string{
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999Z07:00",
+	"2006-01-02",
+}
+
+// timeScanner time.Time 字段扫描器，NULL 和 0000-00-00 零值日期都视为无效，文本按客户端时区 loc 解析
+type timeScanner struct {
+	valid bool
+	t     time.Time
+	loc   *time.Location
+}
+
+// Scan 实现 sql.Scanner 接口
+func (s *timeScanner) Scan(src any) error {
+	s.valid, s.t = false, time.Time{}
+	var text string
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case time.Time:
+		if v.IsZero() {
+			return nil
+		}
+		s.valid, s.t = true, v
+		if s.loc != nil {
+			s.t = v.In(s.loc)
+		}
+		return nil
+	case []byte:
+		text = string(v)
+	case string:
+		text = v
+	default:
+		return fmt.Errorf("cannot convert %T to time.Time", src)
+	}
+
+	if text == "" || strings.HasPrefix(text, "0000-00-00") {
+		return nil
+	}
+	loc := s.loc
+	if loc == nil {
+		loc = time.UTC
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, text, loc); err == nil {
+			s.valid, s.t = true, t
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot parse '%s' as time.Time", text)
+}
+
+// durationScanner time.Duration 字段扫描器，用于 TIME 列，支持负数和超过 24 小时的值（-838:59:59 ~ 838:59:59）
+type durationScanner struct {
+	valid bool
+	d     time.Duration
+}
+
+// Scan 实现 sql.Scanner 接口
+func (s *durationScanner) Scan(src any) error {
+	s.valid, s.d = false, 0
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		d, err := parseMySQLTime(string(v))
+		if err != nil {
+			return err
+		}
+		s.valid, s.d = true, d
+	case string:
+		d, err := parseMySQLTime(v)
+		if err != nil {
+			return err
+		}
+		s.valid, s.d = true, d
+	case int64:
+		// 数值按秒处理，如 TIME_TO_SEC() 的结果
+		s.valid, s.d = true, time.Duration(v)*time.Second
+	default:
+		return fmt.Errorf("cannot convert %T to time.Duration", src)
+	}
+	return nil
+}
+
+// parseMySQLTime 解析 TIME 列的文本格式 [-][H]HH:MM:SS[.ffffff]
+func parseMySQLTime(text string) (time.Duration, error) {
+	s := text
+	neg := strings.HasPrefix(s, "-")
+	if neg {
+		s = s[1:]
+	}
+
+	var frac time.Duration
+	if whole, fraction, ok := strings.Cut(s, "."); ok {
+		s = whole
+		if len(fraction) > 9 {
+			fraction = fraction[:9]
+		}
+		n, err := strconv.ParseInt(fraction+strings.Repeat("0", 9-len(fraction)), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid TIME value '%s'", text)
+		}
+		frac = time.Duration(n)
+	}
+
+	parts := strings.Split(s, ":")
+	if len(parts) != 3 {
+		return 0, fmt.Errorf("invalid TIME value '%s'", text)
+	}
+	var values [3]int64
+	for i, part := range parts {
+		n, err := strconv.ParseInt(part, 10, 64)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid TIME value '%s'", text)
+		}
+		values[i] = n
+	}
+
+	d := time.Duration(values[0])*time.Hour + time.Duration(values[1])*time.Minute + time.Duration(values[2])*time.Second + frac
+	if neg {
+		d = -d
+	}
+	return d, nil
+}
+
+// MySQLTime 作为 TIME 列的查询参数，按 HH:MM:SS[.ffffff] 格式传递
+// time.Duration 本身按纳秒整数传递，与 TIME 列比较或写入 TIME 列时需要转换：smysql.MySQLTime(8 * time.Hour)
+type MySQLTime time.Duration
+
+// Value 实现 driver.Valuer 接口
+func (t MySQLTime) Value() (driver.Value, error) {
+	return formatMySQLTime(time.Duration(t)), nil
+}
+
+// formatMySQLTime 将 time.Duration 格式化为 TIME 列的文本格式，作为查询参数使用
+func formatMySQLTime(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	hours := d / time.Hour
+	minutes := (d % time.Hour) / time.Minute
+	seconds := (d % time.Minute) / time.Second
+	micros := (d % time.Second) / time.Microsecond
+	if micros == 0 {
+		return fmt.Sprintf("%s%02d:%02d:%02d", sign, hours, minutes, seconds)
+	}
+	return fmt.Sprintf("%s%02d:%02d:%02d.%06d", sign, hours, minutes, seconds, micros)
+}
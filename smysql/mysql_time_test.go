@@ -0,0 +1,101 @@
+package smysql_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Xuzan9396/zmysql/smysql"
+)
+
+// TestTimeTypes 测试 DATETIME、DATE、TIME、YEAR 列的映射
+func TestTimeTypes(t *testing.T) {
+	client, err := getTestClient()
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	type TimeRow struct {
+		CreatedAt time.Time      `db:"created_at"`
+		Birthday  time.Time      `db:"birthday"`
+		DeletedAt *time.Time     `db:"deleted_at"`
+		Zero      time.Time      `db:"zero"`
+		Elapsed   time.Duration  `db:"elapsed"`
+		Negative  time.Duration  `db:"negative"`
+		Timeout   *time.Duration `db:"timeout"`
+		Year      int            `db:"year"`
+	}
+
+	t.Run("Scan", func(t *testing.T) {
+		var row TimeRow
+		found, err := client.First(&row, `SELECT
+			CAST('2024-05-06 07:08:09' AS DATETIME) AS created_at,
+			CAST('1990-01-02' AS DATE) AS birthday,
+			NULL AS deleted_at,
+			'0000-00-00 00:00:00' AS zero,
+			CAST('100:30:15.5' AS TIME(1)) AS elapsed,
+			CAST('-01:02:03' AS TIME) AS negative,
+			NULL AS timeout,
+			YEAR('2024-05-06') AS year`)
+		if err != nil || !found {
+			t.Fatalf("First failed: found=%v err=%v", found, err)
+		}
+
+		if !row.CreatedAt.Equal(time.Date(2024, 5, 6, 7, 8, 9, 0, time.Local)) {
+			t.Errorf("Unexpected created_at: %v", row.CreatedAt)
+		}
+		if row.Birthday.Year() != 1990 || row.Birthday.Day() != 2 {
+			t.Errorf("Unexpected birthday: %v", row.Birthday)
+		}
+		if row.DeletedAt != nil || !row.Zero.IsZero() || row.Timeout != nil {
+			t.Errorf("Expected NULL and zero dates to be zero values, got %+v", row)
+		}
+		if row.Elapsed != 100*time.Hour+30*time.Minute+15*time.Second+500*time.Millisecond {
+			t.Errorf("Unexpected elapsed: %v", row.Elapsed)
+		}
+		if row.Negative != -(time.Hour + 2*time.Minute + 3*time.Second) {
+			t.Errorf("Unexpected negative: %v", row.Negative)
+		}
+		if row.Year != 2024 {
+			t.Errorf("Unexpected year: %d", row.Year)
+		}
+	})
+
+	t.Run("ZeroDatePointer", func(t *testing.T) {
+		type ZeroRow struct {
+			Date     *time.Time `db:"zero_date"`
+			DateTime *time.Time `db:"zero_datetime"`
+		}
+		var row ZeroRow
+		found, err := client.First(&row, `SELECT
+			'0000-00-00' AS zero_date,
+			'0000-00-00 00:00:00' AS zero_datetime`)
+		if err != nil || !found {
+			t.Fatalf("First failed: found=%v err=%v", found, err)
+		}
+		if row.Date != nil || row.DateTime != nil {
+			t.Errorf("Expected zero dates to be nil, got date=%v datetime=%v", row.Date, row.DateTime)
+		}
+	})
+
+	t.Run("DurationArg", func(t *testing.T) {
+		var seconds int64
+		found, err := client.FirstCol(&seconds, "SELECT TIME_TO_SEC(?)", smysql.MySQLTime(-90*time.Minute))
+		if err != nil || !found {
+			t.Fatalf("FirstCol failed: found=%v err=%v", found, err)
+		}
+		if seconds != -5400 {
+			t.Errorf("Expected -5400 seconds, got %d", seconds)
+		}
+
+		// 未转换的 time.Duration 按纳秒整数传递，可以写入整数列
+		var nanos int64
+		found, err = client.FirstCol(&nanos, "SELECT ? + 0", 2*time.Second)
+		if err != nil || !found {
+			t.Fatalf("FirstCol failed: found=%v err=%v", found, err)
+		}
+		if nanos != int64(2*time.Second) {
+			t.Errorf("Expected %d nanoseconds, got %d", int64(2*time.Second), nanos)
+		}
+	})
+}
@@ -0,0 +1,122 @@
+package smysql
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+var valuerType = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+
+// typeCodec 自定义类型的读写转换函数
+type typeCodec struct {
+	decode func(src any) (any, error)
+	encode func(v any) (driver.Value, error)
+}
+
+var (
+	typeRegistryMu sync.RWMutex
+	typeRegistry   = make(map[reflect.Type]*typeCodec)
+)
+
+// RegisterType 注册自定义类型 T 的转换函数，适用于 decimal.Decimal、uuid.UUID、枚举、netip.Addr 等类型
+// decode 将驱动返回的原始值（[]byte、int64、float64、time.Time 等）转换为 T，NULL 不会调用 decode，字段保持零值
+// encode 将 T 转换为 driver.Value，作为查询参数时自动调用；decode 或 encode 为 nil 时表示不处理对应方向
+func RegisterType[T any](decode func(src any) (T, error), encode func(T) (driver.Value, error)) {
+	codec := &typeCodec{}
+	if decode != nil {
+		codec.decode = func(src any) (any, error) {
+			return decode(src)
+		}
+	}
+	if encode != nil {
+		codec.encode = func(v any) (driver.Value, error) {
+			return encode(v.(T))
+		}
+	}
+
+	typeRegistryMu.Lock()
+	typeRegistry[reflect.TypeOf((*T)(nil)).Elem()] = codec
+	typeRegistryMu.Unlock()
+}
+
+// lookupCodec 查找已注册的类型转换函数
+func lookupCodec(t reflect.Type) *typeCodec {
+	typeRegistryMu.RLock()
+	defer typeRegistryMu.RUnlock()
+	return typeRegistry[t]
+}
+
+// codecScanner 使用注册的 decode 函数扫描字段
+type codecScanner struct {
+	codec *typeCodec
+	valid bool
+	value any
+}
+
+// Scan 实现 sql.Scanner 接口
+func (s *codecScanner) Scan(src any) error {
+	s.valid = src != nil
+	s.value = nil
+	if !s.valid {
+		return nil
+	}
+	// 驱动返回的 []byte 在下次 Scan 时会被复用，需要拷贝
+	if b, ok := src.([]byte); ok {
+		src = append([]byte(nil), b...)
+	}
+	value, err := s.codec.decode(src)
+	if err != nil {
+		return err
+	}
+	s.value = value
+	return nil
+}
+
+// encodeArg 转换单个查询参数：注册类型调用 encode，只有指针接收者实现 driver.Valuer 的值转为指针
+func encodeArg(arg any) (any, error) {
+	if arg == nil {
+		return nil, nil
+	}
+
+	t := reflect.TypeOf(arg)
+	if codec := lookupCodec(t); codec != nil && codec.encode != nil {
+		value, err := codec.encode(arg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %v", t, err)
+		}
+		return value, nil
+	}
+	if t.Kind() == reflect.Ptr {
+		if codec := lookupCodec(t.Elem()); codec != nil && codec.encode != nil {
+			v := reflect.ValueOf(arg)
+			if v.IsNil() {
+				return nil, nil
+			}
+			return encodeArg(v.Elem().Interface())
+		}
+	}
+	if !t.Implements(valuerType) && reflect.PointerTo(t).Implements(valuerType) {
+		ptr := reflect.New(t)
+		ptr.Elem().Set(reflect.ValueOf(arg))
+		return ptr.Interface(), nil
+	}
+	return arg, nil
+}
+
+// encodeArgs 转换查询参数
+func encodeArgs(args []any) ([]any, error) {
+	if len(args) == 0 {
+		return args, nil
+	}
+	encoded := make([]any, len(args))
+	for i, arg := range args {
+		value, err := encodeArg(arg)
+		if err != nil {
+			return nil, err
+		}
+		encoded[i] = value
+	}
+	return encoded, nil
+}
@@ -0,0 +1,102 @@
+package smysql_test
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Xuzan9396/zmysql/smysql"
+)
+
+// CountryCode 自定义类型，通过 RegisterType 注册转换函数
+type CountryCode struct {
+	Code string
+}
+
+// UpperName 只有指针接收者实现 driver.Valuer 的类型
+type UpperName string
+
+func (n *UpperName) Value() (driver.Value, error) {
+	return strings.ToUpper(string(*n)), nil
+}
+
+func init() {
+	smysql.RegisterType[CountryCode](
+		func(src any) (CountryCode, error) {
+			b, ok := src.([]byte)
+			if !ok {
+				return CountryCode{}, fmt.Errorf("unexpected type %T", src)
+			}
+			return CountryCode{Code: string(b)}, nil
+		},
+		func(c CountryCode) (driver.Value, error) {
+			return c.Code, nil
+		},
+	)
+}
+
+// TestRegisterType 测试自定义类型注册
+func TestRegisterType(t *testing.T) {
+	client, err := getTestClient()
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if err := setupTestData(client); err != nil {
+		t.Fatalf("failed to setup test data: %v", err)
+	}
+
+	type City struct {
+		ID          uint         `db:"id"`
+		CountryCode CountryCode  `db:"country_code"`
+		WikiCode    *CountryCode `db:"wikiDataId"`
+	}
+
+	t.Run("DecodeAndEncode", func(t *testing.T) {
+		var cities []City
+		err := client.Find(&cities, "SELECT id, country_code, wikiDataId FROM cities_test WHERE country_code = ?", CountryCode{Code: "JP"})
+		if err != nil {
+			t.Fatalf("Find with registered type failed: %v", err)
+		}
+		if len(cities) != 2 {
+			t.Fatalf("Expected 2 cities, got %d", len(cities))
+		}
+		for _, city := range cities {
+			if city.CountryCode.Code != "JP" || city.WikiCode == nil {
+				t.Errorf("Unexpected decoded city: %+v", city)
+			}
+		}
+	})
+
+	t.Run("NullLeavesNil", func(t *testing.T) {
+		if _, err := client.Exec("UPDATE cities_test SET wikiDataId = NULL WHERE name = ?", "Tokyo"); err != nil {
+			t.Fatalf("Exec failed: %v", err)
+		}
+
+		var city City
+		found, err := client.First(&city, "SELECT id, country_code, wikiDataId FROM cities_test WHERE name = ?", "Tokyo")
+		if err != nil || !found {
+			t.Fatalf("First failed: found=%v err=%v", found, err)
+		}
+		if city.WikiCode != nil {
+			t.Errorf("Expected nil for NULL column, got %+v", city.WikiCode)
+		}
+	})
+
+	t.Run("PointerValuerInStructWrite", func(t *testing.T) {
+		arg := struct {
+			Name UpperName `db:"name"`
+		}{Name: "tokyo"}
+
+		var city City
+		found, err := client.FirstNamed(&city, "SELECT id, country_code FROM cities_test WHERE BINARY name = :name", arg)
+		if err != nil {
+			t.Fatalf("FirstNamed failed: %v", err)
+		}
+		if found {
+			t.Errorf("Expected upper-cased name TOKYO not to match, got %+v", city)
+		}
+	})
+}
@@ -0,0 +1,59 @@
+package smysql_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Xuzan9396/zmysql/smysql"
+)
+
+// TestUnsignedBigint 测试 BIGINT UNSIGNED 超过 int64 范围的值以及窄类型溢出
+func TestUnsignedBigint(t *testing.T) {
+	client, err := getTestClient()
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	const maxQuery = "SELECT CAST(18446744073709551615 AS UNSIGNED) AS id, 300 AS flag"
+
+	t.Run("StructField", func(t *testing.T) {
+		var row struct {
+			ID uint64 `db:"id"`
+		}
+		found, err := client.First(&row, maxQuery)
+		if err != nil || !found {
+			t.Fatalf("First failed: found=%v err=%v", found, err)
+		}
+		if row.ID != 18446744073709551615 {
+			t.Errorf("Expected max uint64, got %d", row.ID)
+		}
+	})
+
+	t.Run("Overflow", func(t *testing.T) {
+		var row struct {
+			Flag uint8 `db:"flag"`
+		}
+		_, err := client.First(&row, maxQuery)
+		if err == nil || !strings.Contains(err.Error(), "flag") {
+			t.Errorf("Expected overflow error naming column flag, got %v", err)
+		}
+	})
+
+	t.Run("Generics", func(t *testing.T) {
+		ids, err := smysql.FindArray[uint64](client, "id", maxQuery)
+		if err != nil || len(ids) != 1 || ids[0] != 18446744073709551615 {
+			t.Errorf("FindArray[uint64] failed: ids=%v err=%v", ids, err)
+		}
+
+		id, found, err := smysql.FirstColAny[uint64](client, "SELECT CAST(18446744073709551615 AS UNSIGNED)")
+		if err != nil || !found || id != 18446744073709551615 {
+			t.Errorf("FirstColAny[uint64] failed: id=%d err=%v", id, err)
+		}
+
+		m, err := smysql.FindMap[uint64, int64](client, "id", "flag", maxQuery)
+		if err != nil || m[18446744073709551615] != 300 {
+			t.Errorf("FindMap[uint64] failed: m=%v err=%v", m, err)
+		}
+	})
+}
@@ -0,0 +1,283 @@
+package smysql
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// WithAllowedColumns 设置表允许更新的列白名单，设置后 UpdateMap 只校验白名单而不再查询表结构
+func WithAllowedColumns(table string, columns ...string) func(*MySQLClient) {
+	return func(client *MySQLClient) {
+		allowed := make(map[string]string, len(columns))
+		for _, col := range columns {
+			allowed[strings.ToLower(col)] = col
+		}
+		client.allowedColumns[strings.ToLower(table)] = allowed
+	}
+}
+
+// quoteIdent 使用反引号转义标识符，支持 schema.table 形式
+func quoteIdent(name string) string {
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		parts[i] = "`" + strings.ReplaceAll(part, "`", "``") + "`"
+	}
+	return strings.Join(parts, ".")
+}
+
+// tableColumns 获取表允许使用的列，优先使用白名单，否则查询 information_schema 并缓存
+// 返回值为 {小写列名 -> 实际列名}
+func (client *MySQLClient) tableColumns(ctx context.Context, table string) (map[string]string, error) {
+	key := strings.ToLower(table)
+
+	client.mu.RLock()
+	columns, ok := client.allowedColumns[key]
+	if !ok {
+		columns, ok = client.columnsCache[key]
+	}
+	client.mu.RUnlock()
+	if ok {
+		return columns, nil
+	}
+
+	schemaExpr, tableName := "DATABASE()", table
+	var args []any
+	if idx := strings.LastIndex(table, "."); idx >= 0 {
+		schemaExpr, tableName = "?", table[idx+1:]
+		args = append(args, table[:idx])
+	}
+	args = append(args, tableName)
+	query := fmt.Sprintf("SELECT COLUMN_NAME FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = %s AND TABLE_NAME = ?", schemaExpr)
+	client.debugLog(query, args...)
+
+	rows, err := client.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query table columns: %v", err)
+	}
+	defer rows.Close()
+
+	columns = make(map[string]string)
+	for rows.Next() {
+		var col string
+		if err := rows.Scan(&col); err != nil {
+			return nil, fmt.Errorf("failed to scan column name: %v", err)
+		}
+		columns[strings.ToLower(col)] = col
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %v", err)
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("table '%s' not found or has no columns", table)
+	}
+
+	client.mu.Lock()
+	client.columnsCache[key] = columns
+	client.mu.Unlock()
+	return columns, nil
+}
+
+// UpdateMap 根据 map 构建 UPDATE ... SET a=?, b=? 并执行，返回受影响行数
+// 列名会按白名单或表的实际列校验并加反引号，where 不能为空以避免误更新整表
+func (client *MySQLClient) UpdateMap(ctx context.Context, table string, data map[string]any, where string, args ...any) (int64, error) {
+	if table == "" {
+		return 0, fmt.Errorf("table cannot be empty")
+	}
+	if len(data) == 0 {
+		return 0, fmt.Errorf("data cannot be empty")
+	}
+	if strings.TrimSpace(where) == "" {
+		return 0, fmt.Errorf("where cannot be empty")
+	}
+
+	columns, err := client.tableColumns(ctx, table)
+	if err != nil {
+		return 0, err
+	}
+
+	// 排序保证生成的 SQL 稳定
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	sets := make([]string, 0, len(keys))
+	setArgs := make([]any, 0, len(keys))
+	for _, k := range keys {
+		col, ok := columns[strings.ToLower(k)]
+		if !ok {
+			return 0, fmt.Errorf("column '%s' is not allowed for table '%s'", k, table)
+		}
+		sets = append(sets, quoteIdent(col)+" = ?")
+		setArgs = append(setArgs, data[k])
+	}
+
+	// SET 的值按原样绑定，只有 where 中的切片参数展开为 IN 列表
+	setArgs, err = encodeArgs(setArgs)
+	if err != nil {
+		return 0, err
+	}
+	where, whereArgs, err := expandArgs(where, args)
+	if err != nil {
+		return 0, err
+	}
+	execArgs := append(setArgs, whereArgs...)
+
+	query := fmt.Sprintf("UPDATE %s SET %s WHERE %s", quoteIdent(table), strings.Join(sets, ", "), where)
+	client.debugLog(query, execArgs...)
+
+	stmt, err := client.DB.PrepareContext(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prepare query: %v", err)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, execArgs...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to execute query: %v", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get affected rows: %v", err)
+	}
+	return rowsAffected, nil
+}
+
+// Snapshot 记录结构体 db 字段在某一时刻的值，用于只更新发生变化的字段
+type Snapshot struct {
+	dest    reflect.Value
+	mapping map[string]*fieldInfo
+	values  map[string]any
+}
+
+// Snapshot 对通过 First 等方法加载的结构体做快照，dest 必须为结构体指针
+func (client *MySQLClient) Snapshot(dest any) (*Snapshot, error) {
+	destValue := reflect.ValueOf(dest)
+	if destValue.Kind() != reflect.Ptr || destValue.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("dest must be a pointer to a struct")
+	}
+
+	snap := &Snapshot{
+		dest:    destValue.Elem(),
+		mapping: client.getFieldsMapping(destValue.Elem().Type()).columns,
+	}
+	snap.reset()
+	return snap, nil
+}
+
+// reset 以当前字段值作为新的快照
+func (s *Snapshot) reset() {
+	s.values = make(map[string]any, len(s.mapping))
+	for col, info := range s.mapping {
+		s.values[col] = snapshotValue(s.dest, info)
+	}
+}
+
+// snapshotValue 复制字段值，指针、slice、map 深拷贝，避免原地修改后快照随之改变，嵌入的指针为 nil 时返回 nil
+// json 字段保存编码后的 JSON 字符串，注册类型保存 encode 后的值，两者都可以直接作为更新参数
+func snapshotValue(dest reflect.Value, info *fieldInfo) any {
+	field, ok := fieldByIndex(dest, info.index)
+	if !ok {
+		return nil
+	}
+	if info.json {
+		if value, err := jsonArg(field); err == nil {
+			return value
+		}
+	}
+	codecType := field.Type()
+	if codecType.Kind() == reflect.Ptr {
+		codecType = codecType.Elem()
+	}
+	if codec := lookupCodec(codecType); codec != nil && codec.encode != nil {
+		if value, err := encodeArg(field.Interface()); err == nil {
+			if b, ok := value.([]byte); ok && b != nil {
+				return append([]byte(nil), b...)
+			}
+			return value
+		}
+	}
+	return deepCopy(field).Interface()
+}
+
+// deepCopy 递归复制指针指向的值、slice、map 和数组的元素，其他类型按值复制
+func deepCopy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.New(v.Type().Elem())
+		copied.Elem().Set(deepCopy(v.Elem()))
+		return copied
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.New(v.Type()).Elem()
+		copied.Set(deepCopy(v.Elem()))
+		return copied
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			copied.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return copied
+	case reflect.Array:
+		copied := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			copied.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return copied
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			copied.SetMapIndex(iter.Key(), deepCopy(iter.Value()))
+		}
+		return copied
+	}
+	return v
+}
+
+// Changed 返回自快照以来发生变化的字段，格式为 {列名 -> 当前值}
+func (s *Snapshot) Changed() map[string]any {
+	changed := make(map[string]any)
+	for col, info := range s.mapping {
+		current := snapshotValue(s.dest, info)
+		if !reflect.DeepEqual(s.values[col], current) {
+			changed[col] = current
+		}
+	}
+	return changed
+}
+
+// UpdateChanged 只更新快照以来发生变化的字段，没有变化时不执行 SQL，更新成功后刷新快照
+func (client *MySQLClient) UpdateChanged(ctx context.Context, table string, snap *Snapshot, where string, args ...any) (int64, error) {
+	if snap == nil {
+		return 0, fmt.Errorf("snapshot cannot be nil")
+	}
+
+	changed := snap.Changed()
+	if len(changed) == 0 {
+		return 0, nil
+	}
+
+	rowsAffected, err := client.UpdateMap(ctx, table, changed, where, args...)
+	if err != nil {
+		return 0, err
+	}
+	snap.reset()
+	return rowsAffected, nil
+}
@@ -0,0 +1,188 @@
+package smysql_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Xuzan9396/zmysql/smysql"
+)
+
+// TestUpdateMap 测试 UpdateMap 方法
+func TestUpdateMap(t *testing.T) {
+	client, err := getTestClient()
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if err := setupTestData(client); err != nil {
+		t.Fatalf("failed to setup test data: %v", err)
+	}
+
+	ctx := context.Background()
+
+	t.Run("UpdateByTableColumns", func(t *testing.T) {
+		affected, err := client.UpdateMap(ctx, "cities_test", map[string]any{
+			"state_code": "BJX",
+			"flag":       false,
+		}, "name = ?", "Beijing")
+		if err != nil {
+			t.Fatalf("UpdateMap failed: %v", err)
+		}
+		if affected != 1 {
+			t.Errorf("Expected 1 affected row, got %d", affected)
+		}
+
+		stateCode, _, err := client.FirstColString("SELECT state_code FROM cities_test WHERE name = ?", "Beijing")
+		if err != nil {
+			t.Fatalf("FirstColString failed: %v", err)
+		}
+		if stateCode != "BJX" {
+			t.Errorf("Expected state_code BJX, got %s", stateCode)
+		}
+	})
+
+	t.Run("SliceValueNotExpanded", func(t *testing.T) {
+		// SET 中的切片值不会展开为 ?, ?，驱动不支持的切片返回错误，而不是只写入第一个元素
+		for _, value := range []any{[]string{"a"}, []string{"a", "b"}, []string{}} {
+			if _, err := client.UpdateMap(ctx, "cities_test", map[string]any{"state_code": value}, "name = ?", "Shanghai"); err == nil {
+				t.Errorf("Expected error for slice value %v, but got none", value)
+			}
+		}
+		stateCode, _, err := client.FirstColString("SELECT state_code FROM cities_test WHERE name = ?", "Shanghai")
+		if err != nil {
+			t.Fatalf("FirstColString failed: %v", err)
+		}
+		if stateCode != "SH" {
+			t.Errorf("Expected state_code SH to be unchanged, got %s", stateCode)
+		}
+
+		// []byte 作为单个值写入，where 中的切片仍然展开为 IN 列表
+		affected, err := client.UpdateMap(ctx, "cities_test", map[string]any{"wikiDataId": []byte("Q1")},
+			"name IN (?)", []string{"Tokyo", "Osaka"})
+		if err != nil {
+			t.Fatalf("UpdateMap failed: %v", err)
+		}
+		if affected != 2 {
+			t.Errorf("Expected 2 affected rows, got %d", affected)
+		}
+	})
+
+	t.Run("RejectUnknownColumn", func(t *testing.T) {
+		_, err := client.UpdateMap(ctx, "cities_test", map[string]any{
+			"name = 'x', flag": 1,
+		}, "name = ?", "Beijing")
+		if err == nil {
+			t.Error("Expected error for unknown column, but got none")
+		}
+		t.Logf("Unknown column error (expected): %v", err)
+	})
+
+	t.Run("RejectEmptyWhere", func(t *testing.T) {
+		_, err := client.UpdateMap(ctx, "cities_test", map[string]any{"flag": 1}, "")
+		if err == nil {
+			t.Error("Expected error for empty where, but got none")
+		}
+	})
+
+	t.Run("AllowedColumns", func(t *testing.T) {
+		limited, err := smysql.Conn("root", "123456", "127.0.0.1:3326", "weather",
+			smysql.WithAllowedColumns("cities_test", "state_code"))
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+		defer limited.Close()
+
+		if _, err := limited.UpdateMap(ctx, "cities_test", map[string]any{"flag": 1}, "name = ?", "Tokyo"); err == nil {
+			t.Error("Expected error for column outside allowlist, but got none")
+		}
+		if _, err := limited.UpdateMap(ctx, "cities_test", map[string]any{"state_code": "TKX"}, "name = ?", "Tokyo"); err != nil {
+			t.Errorf("UpdateMap with allowed column failed: %v", err)
+		}
+	})
+}
+
+// TestUpdateChanged 测试快照差异更新
+func TestUpdateChanged(t *testing.T) {
+	client, err := getTestClient()
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if err := setupTestData(client); err != nil {
+		t.Fatalf("failed to setup test data: %v", err)
+	}
+
+	ctx := context.Background()
+
+	var city CityTest
+	found, err := client.First(&city, "SELECT * FROM cities_test WHERE name = ?", "Shanghai")
+	if err != nil || !found {
+		t.Fatalf("First failed: found=%v err=%v", found, err)
+	}
+
+	snap, err := client.Snapshot(&city)
+	if err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+
+	if changed := snap.Changed(); len(changed) != 0 {
+		t.Errorf("Expected no changes, got %v", changed)
+	}
+
+	city.StateCode = "SHX"
+	changed := snap.Changed()
+	if len(changed) != 1 || changed["state_code"] != "SHX" {
+		t.Errorf("Expected only state_code changed, got %v", changed)
+	}
+
+	affected, err := client.UpdateChanged(ctx, "cities_test", snap, "id = ?", city.ID)
+	if err != nil {
+		t.Fatalf("UpdateChanged failed: %v", err)
+	}
+	if affected != 1 {
+		t.Errorf("Expected 1 affected row, got %d", affected)
+	}
+
+	// 快照已刷新，再次更新不会执行 SQL
+	affected, err = client.UpdateChanged(ctx, "cities_test", snap, "id = ?", city.ID)
+	if err != nil {
+		t.Fatalf("UpdateChanged without changes failed: %v", err)
+	}
+	if affected != 0 {
+		t.Errorf("Expected 0 affected rows, got %d", affected)
+	}
+
+	// 原地修改指针字段指向的值也能被发现
+	type CityPtr struct {
+		ID        int64   `db:"id"`
+		StateCode *string `db:"state_code"`
+	}
+	var cityPtr CityPtr
+	found, err = client.First(&cityPtr, "SELECT id, state_code FROM cities_test WHERE id = ?", city.ID)
+	if err != nil || !found || cityPtr.StateCode == nil {
+		t.Fatalf("First failed: found=%v err=%v", found, err)
+	}
+	snap, err = client.Snapshot(&cityPtr)
+	if err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+
+	*cityPtr.StateCode = "SHY"
+	changed = snap.Changed()
+	if len(changed) != 1 {
+		t.Fatalf("Expected only state_code changed, got %v", changed)
+	}
+	if v, ok := changed["state_code"].(*string); !ok || *v != "SHY" {
+		t.Errorf("Expected state_code SHY, got %v", changed["state_code"])
+	}
+
+	affected, err = client.UpdateChanged(ctx, "cities_test", snap, "id = ?", cityPtr.ID)
+	if err != nil {
+		t.Fatalf("UpdateChanged failed: %v", err)
+	}
+	if affected != 1 {
+		t.Errorf("Expected 1 affected row, got %d", affected)
+	}
+}